@@ -0,0 +1,178 @@
+package mgraph
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrWouldCreateCycle = errors.New("edge would create a cycle")
+
+// topologicalOrder keeps a topological order of the graph up to date as edges are
+// added, following Pearce and Kelly's dynamic algorithm: only the vertices whose
+// position lies between the endpoints of a violating edge are visited and reordered.
+type topologicalOrder struct {
+	positions map[VertexID]int
+	next      int
+}
+
+func (g *graph[V, E]) EnsureAcyclicity(enable bool) error {
+	return g.ensureAcyclicityBy(nil, enable)
+}
+
+func (g *graph[V, E]) ensureAcyclicityBy(t *tx[V, E], enable bool) error {
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while ensuring acyclicity: %w", err)
+	}
+	g.seal()
+	if !enable {
+		g.recordAcyclicity()
+		g.properties.acyclicity = false
+		g.order = nil
+		unlock()
+		return nil
+	}
+	if g.properties.acyclicity {
+		unlock()
+		return nil
+	}
+	order, ok := g.topologicalOrder()
+	if ok {
+		g.recordAcyclicity()
+		g.properties.acyclicity = true
+		g.order = order
+	}
+	unlock()
+	if !ok {
+		cycle, _ := FindCycle[V, E](g)
+		return fmt.Errorf("error while ensuring acyclicity: %w", &CycleError{Cycle: cycle})
+	}
+	return nil
+}
+
+// recordAcyclicity records how to restore the current acyclicity. An order set
+// aside by disabling acyclicity is valid again once every later change is undone.
+func (g *graph[V, E]) recordAcyclicity() {
+	acyclicity, order := g.properties.acyclicity, g.order
+	g.record(func() {
+		g.properties.acyclicity, g.order = acyclicity, order
+	})
+}
+
+func (g *graph[V, E]) EnsuresAcyclicity() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.properties.acyclicity
+}
+
+func (g *graph[V, E]) topologicalOrder() (*topologicalOrder, bool) {
+	inDegree := make(map[VertexID]int, g.vertices.len())
+	for id := range g.vertices.all() {
+		inDegree[id] = 0
+	}
+	for e := range g.edges.values() {
+		if _, ok := inDegree[e.from]; !ok {
+			inDegree[e.from] = 0
+		}
+		if e.directed {
+			inDegree[e.to]++
+		} else if _, ok := inDegree[e.to]; !ok {
+			inDegree[e.to] = 0
+		}
+	}
+	var ready []VertexID
+	for id, d := range inDegree {
+		if d == 0 {
+			ready = append(ready, id)
+		}
+	}
+	order := &topologicalOrder{positions: make(map[VertexID]int, len(inDegree))}
+	for len(ready) > 0 {
+		id := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		order.position(id)
+		for e := range g.edgesFrom[id].values() {
+			if !e.directed {
+				continue
+			}
+			inDegree[e.to]--
+			if inDegree[e.to] == 0 {
+				ready = append(ready, e.to)
+			}
+		}
+	}
+	return order, len(order.positions) == len(inDegree)
+}
+
+func (o *topologicalOrder) position(id VertexID) int {
+	p, ok := o.positions[id]
+	if !ok {
+		p = o.next
+		o.positions[id] = p
+		o.next++
+	}
+	return p
+}
+
+func (o *topologicalOrder) clone() *topologicalOrder {
+	if o == nil {
+		return nil
+	}
+	positions := make(map[VertexID]int, len(o.positions))
+	for id, p := range o.positions {
+		positions[id] = p
+	}
+	return &topologicalOrder{positions: positions, next: o.next}
+}
+
+// orderEdge reorders the vertices so that from precedes to, failing when to already reaches from.
+func (g *graph[V, E]) orderEdge(from, to VertexID) error {
+	o := g.order
+	if from == to {
+		return ErrWouldCreateCycle
+	}
+	lower, upper := o.position(to), o.position(from)
+	if upper < lower {
+		return nil
+	}
+	forward := g.reach(to, true, func(id VertexID) bool { return o.position(id) <= upper })
+	if slices.Contains(forward, from) {
+		return ErrWouldCreateCycle
+	}
+	backward := g.reach(from, false, func(id VertexID) bool { return o.position(id) >= lower })
+	byPosition := func(a, b VertexID) int { return cmp.Compare(o.positions[a], o.positions[b]) }
+	slices.SortFunc(forward, byPosition)
+	slices.SortFunc(backward, byPosition)
+	moved := append(backward, forward...)
+	positions := make([]int, 0, len(moved))
+	for _, id := range moved {
+		positions = append(positions, o.positions[id])
+	}
+	slices.Sort(positions)
+	for i, id := range moved {
+		o.positions[id] = positions[i]
+	}
+	return nil
+}
+
+// reach collects the vertices reachable from start, following outgoing edges when
+// forward is set and incoming ones otherwise, without leaving the bounded region.
+func (g *graph[V, E]) reach(start VertexID, forward bool, bounded func(id VertexID) bool) []VertexID {
+	adjacency, next := g.edgesTo, func(e *edge[V, E]) VertexID { return e.from }
+	if forward {
+		adjacency, next = g.edgesFrom, func(e *edge[V, E]) VertexID { return e.to }
+	}
+	visited := map[VertexID]bool{start: true}
+	found := []VertexID{start}
+	for i := 0; i < len(found); i++ {
+		for e := range adjacency[found[i]].values() {
+			if n := next(e); e.directed && !visited[n] && bounded(n) {
+				visited[n] = true
+				found = append(found, n)
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,96 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestGraph_EnsureAcyclicity(t *testing.T) {
+	g := New()
+	if g.EnsuresAcyclicity() {
+		t.Fatalf("EnsuresAcyclicity() expected to return false on new graph, got: %v", g.EnsuresAcyclicity())
+	}
+	if err := g.EnsureAcyclicity(true); err != nil {
+		t.Fatalf("EnsureAcyclicity() expected no error, got: %v", err)
+	}
+	if !g.EnsuresAcyclicity() {
+		t.Fatalf("EnsuresAcyclicity() expected to return true, got: %v", g.EnsuresAcyclicity())
+	}
+	_ = g.EnsureAcyclicity(false)
+	if g.EnsuresAcyclicity() {
+		t.Fatalf("EnsuresAcyclicity() expected to return false, got: %v", g.EnsuresAcyclicity())
+	}
+}
+
+func TestGraph_EnsureAcyclicity_ErrorCycleDetected(t *testing.T) {
+	g := newHierarchyGraph()
+	_, _ = g.AddEdge("edge6", "chapter1", "season1")
+	err := g.EnsureAcyclicity(true)
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("EnsureAcyclicity() expected error ErrCycleDetected, got: %v", err)
+	}
+	if g.EnsuresAcyclicity() {
+		t.Fatalf("EnsuresAcyclicity() expected to return false, got: %v", g.EnsuresAcyclicity())
+	}
+}
+
+func TestGraph_AddEdgeOnAcyclicGraph_ErrorWouldCreateCycle(t *testing.T) {
+	g := newHierarchyGraph()
+	_ = g.EnsureAcyclicity(true)
+	_, err := g.AddEdge("edge6", "chapter1", "series")
+	if !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle, got: %v", err)
+	}
+	if g.Edge("edge6") != nil {
+		t.Fatalf("AddEdge() expected to not add edge, got: %v", g.Edge("edge6"))
+	}
+	_, err = g.AddEdge("edge6", "chapter1", "chapter1")
+	if !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle for loop, got: %v", err)
+	}
+	_, err = g.AddEdge("edge6", "season2", "chapter1")
+	if err != nil {
+		t.Fatalf("AddEdge() expected no error, got: %v", err)
+	}
+	_, err = g.AddEdge("edge7", "chapter3", "season1")
+	if err != nil {
+		t.Fatalf("AddEdge() expected no error, got: %v", err)
+	}
+	_, err = g.AddEdge("edge8", "chapter1", "season2")
+	if !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle, got: %v", err)
+	}
+}
+
+func TestGraph_AddEdgeOnAcyclicGraph_KeepsTopologicalOrder(t *testing.T) {
+	g := NewTyped[any, any]().(*graph[any, any])
+	_ = g.EnsureAcyclicity(true)
+	for i := 0; i < 10; i++ {
+		_, _ = g.AddVertex(VertexID(fmt.Sprint(i)))
+	}
+	for i := 9; i > 0; i-- {
+		_, err := g.AddEdge(EdgeID(fmt.Sprint(i)), VertexID(fmt.Sprint(i)), VertexID(fmt.Sprint(i-1)))
+		if err != nil {
+			t.Fatalf("AddEdge() expected no error, got: %v", err)
+		}
+	}
+	for _, e := range g.Edges() {
+		if g.order.positions[e.From()] >= g.order.positions[e.To()] {
+			t.Fatalf("AddEdge() expected %s to precede %s, got: %v", e.From(), e.To(), g.order.positions)
+		}
+	}
+	_, err := g.AddEdge("10", "0", "9")
+	if !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle, got: %v", err)
+	}
+	g.RemoveEdge("5")
+	if _, err = g.AddEdge("10", "0", "9"); err != nil {
+		t.Fatalf("AddEdge() expected no error after removing edge, got: %v", err)
+	}
+	sorted, _ := TopologicalSort[any, any](g)
+	if slices.Index(sorted, "0") > slices.Index(sorted, "9") {
+		t.Fatalf("TopologicalSort() expected 0 before 9, got: %v", sorted)
+	}
+}
@@ -0,0 +1,235 @@
+package mgraph
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+)
+
+type Ordering int
+
+const (
+	Unordered Ordering = iota
+	InsertionOrder
+	SortedByID
+)
+
+// collection is the keyed storage behind a graph; a nil collection is empty.
+// Unordered collections are plain maps. Ordered ones also keep their keys in a
+// slice, where removals only leave a tombstone that is compacted away once
+// tombstones outnumber live keys, so they stay O(1) amortized. Collections sorted
+// by ID append their keys unsorted and sort them on enumeration, caching the
+// sorted keys until keys are added out of order, so that adding n keys in any
+// order costs O(n log n) overall. Entries left over by removals, including the
+// duplicates of keys added back, all count as tombstones.
+type collection[K cmp.Ordered, T any] struct {
+	ordering Ordering
+	items    map[K]slot[T]
+	entries  []entry[K]
+	seq      uint64
+	version  uint64
+	// mu guards the sorted keys, which readers build concurrently; they are up to
+	// date while sortedVersion is version, and never share memory with entries.
+	mu            sync.Mutex
+	sorted        []entry[K]
+	sortedVersion uint64
+}
+
+type slot[T any] struct {
+	value T
+	seq   uint64
+}
+
+type entry[K cmp.Ordered] struct {
+	key K
+	seq uint64
+}
+
+func newCollection[K cmp.Ordered, T any](ordering Ordering) *collection[K, T] {
+	return &collection[K, T]{
+		ordering: ordering,
+		items:    make(map[K]slot[T]),
+	}
+}
+
+func (c *collection[K, T]) len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.items)
+}
+
+func (c *collection[K, T]) get(key K) (T, bool) {
+	if c == nil {
+		var zero T
+		return zero, false
+	}
+	s, ok := c.items[key]
+	return s.value, ok
+}
+
+func (c *collection[K, T]) set(key K, value T) {
+	c.insert(key, value, c.seq+1)
+}
+
+// insert adds a key with a sequence number, unique among the keys ever added,
+// which places it in insertion order. Graphs number their elements once for all
+// of their collections, so that removed elements are put back in place when they
+// are restored. Updating the value of a key keeps its place.
+func (c *collection[K, T]) insert(key K, value T, seq uint64) {
+	if s, ok := c.items[key]; ok || c.ordering == Unordered {
+		s.value = value
+		c.items[key] = s
+		return
+	}
+	c.seq = max(c.seq, seq)
+	c.items[key] = slot[T]{value: value, seq: seq}
+	e := entry[K]{key: key, seq: seq}
+	if c.ordering == SortedByID {
+		c.entries = append(c.entries, e)
+		if n := len(c.sorted); c.sortedVersion == c.version && (n == 0 || c.sorted[n-1].key < key) {
+			c.sorted = append(c.sorted, e)
+			c.sortedVersion++
+		}
+		c.version++
+		return
+	}
+	if n := len(c.entries); n == 0 || c.entries[n-1].seq < seq {
+		c.entries = append(c.entries, e)
+		return
+	}
+	i, found := slices.BinarySearchFunc(c.entries, seq, func(e entry[K], seq uint64) int { return cmp.Compare(e.seq, seq) })
+	if found {
+		return
+	}
+	c.entries = slices.Insert(c.entries, i, e)
+	c.version++
+}
+
+func (c *collection[K, T]) delete(key K) {
+	if _, ok := c.items[key]; !ok {
+		return
+	}
+	delete(c.items, key)
+	if c.ordering == Unordered {
+		return
+	}
+	if len(c.entries)-len(c.items) > len(c.items) {
+		if c.ordering == SortedByID {
+			c.entries = slices.Clone(c.ordered())
+		}
+		c.entries = slices.DeleteFunc(c.entries, func(e entry[K]) bool { return !c.live(e) })
+		c.version++
+	}
+}
+
+func (c *collection[K, T]) live(e entry[K]) bool {
+	s, ok := c.items[e.key]
+	return ok && s.seq == e.seq
+}
+
+// ordered returns the entries of an ordered collection in its order.
+func (c *collection[K, T]) ordered() []entry[K] {
+	if c.ordering != SortedByID {
+		return c.entries
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sortedVersion != c.version {
+		sorted := slices.DeleteFunc(slices.Clone(c.entries), func(e entry[K]) bool { return !c.live(e) })
+		slices.SortFunc(sorted, func(a, b entry[K]) int { return cmp.Compare(a.key, b.key) })
+		c.sorted = slices.CompactFunc(sorted, func(a, b entry[K]) bool { return a.key == b.key })
+		c.sortedVersion = c.version
+	}
+	return c.sorted
+}
+
+// compare orders two keys of an ordered collection the way they are enumerated.
+func (c *collection[K, T]) compare(a, b K) int {
+	if c.ordering == InsertionOrder {
+		return cmp.Compare(c.items[a].seq, c.items[b].seq)
+	}
+	return cmp.Compare(a, b)
+}
+
+// all enumerates the collection in its order. Mutations made while iterating are
+// allowed: when they move the entries around, iteration resumes right after the
+// last yielded one.
+func (c *collection[K, T]) all() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		if c == nil {
+			return
+		}
+		if c.ordering == Unordered {
+			for k, s := range c.items {
+				if !yield(k, s.value) {
+					return
+				}
+			}
+			return
+		}
+		version := c.version
+		entries := c.ordered()
+		for i := 0; i < len(entries); i++ {
+			e := entries[i]
+			if !c.live(e) {
+				continue
+			}
+			if !yield(e.key, c.items[e.key].value) {
+				return
+			}
+			if c.ordering == InsertionOrder {
+				entries = c.entries
+			}
+			if c.version != version {
+				version = c.version
+				entries = c.ordered()
+				i = resume(c.ordering, entries, e) - 1
+			}
+		}
+	}
+}
+
+// resume returns the index of the entry following the last yielded one.
+func resume[K cmp.Ordered](ordering Ordering, entries []entry[K], last entry[K]) int {
+	var i int
+	var found bool
+	if ordering == InsertionOrder {
+		i, found = slices.BinarySearchFunc(entries, last.seq, func(e entry[K], seq uint64) int { return cmp.Compare(e.seq, seq) })
+	} else {
+		i, found = slices.BinarySearchFunc(entries, last.key, func(e entry[K], key K) int { return cmp.Compare(e.key, key) })
+	}
+	if found {
+		i++
+	}
+	return i
+}
+
+func (c *collection[K, T]) values() iter.Seq[T] {
+	return values(c.all())
+}
+
+// groups partitions items into collections, such as the edges leaving each vertex
+// or the vertices carrying each label. Empty collections are dropped.
+type groups[G comparable, K cmp.Ordered, T any] map[G]*collection[K, T]
+
+func (gs groups[G, K, T]) add(group G, key K, value T, seq uint64, ordering Ordering) {
+	c, ok := gs[group]
+	if !ok {
+		c = newCollection[K, T](ordering)
+		gs[group] = c
+	}
+	c.insert(key, value, seq)
+}
+
+func (gs groups[G, K, T]) remove(group G, key K) {
+	c, ok := gs[group]
+	if !ok {
+		return
+	}
+	c.delete(key)
+	if c.len() == 0 {
+		delete(gs, group)
+	}
+}
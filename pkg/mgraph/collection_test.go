@@ -0,0 +1,114 @@
+package mgraph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func collectKeys(c *collection[string, int]) (keys []string) {
+	for k := range c.all() {
+		keys = append(keys, k)
+	}
+	return
+}
+
+func TestCollection_InsertionOrder(t *testing.T) {
+	c := newCollection[string, int](InsertionOrder)
+	c.set("c", 1)
+	c.set("a", 2)
+	c.set("b", 3)
+	c.set("a", 4)
+	if keys := collectKeys(c); !slices.Equal(keys, []string{"c", "a", "b"}) {
+		t.Fatalf("all() expected [c a b], got: %v", keys)
+	}
+	c.delete("a")
+	c.set("a", 5)
+	if keys := collectKeys(c); !slices.Equal(keys, []string{"c", "b", "a"}) {
+		t.Fatalf("all() expected [c b a], got: %v", keys)
+	}
+	if v, _ := c.get("a"); v != 5 || c.len() != 3 {
+		t.Fatalf("get() expected 5 with 3 items, got: %v with %d items", v, c.len())
+	}
+}
+
+func TestCollection_SortedByID(t *testing.T) {
+	c := newCollection[string, int](SortedByID)
+	c.set("c", 1)
+	c.set("a", 2)
+	c.set("b", 3)
+	if keys := collectKeys(c); !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("all() expected [a b c], got: %v", keys)
+	}
+	c.delete("b")
+	c.set("d", 4)
+	c.set("b", 5)
+	if keys := collectKeys(c); !slices.Equal(keys, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("all() expected [a b c d], got: %v", keys)
+	}
+}
+
+func TestCollection_SortedByID_OutOfOrder(t *testing.T) {
+	c := newCollection[string, int](SortedByID)
+	for i := 999; i >= 0; i-- {
+		c.set(fmt.Sprintf("%03d", i), i)
+	}
+	c.delete("500")
+	c.insert("500", 500, 500)
+	keys := collectKeys(c)
+	if len(keys) != 1000 || !slices.IsSorted(keys) {
+		t.Fatalf("all() expected the 1000 keys sorted once, got: %d keys", len(keys))
+	}
+}
+
+func TestCollection_DeleteCompactsTombstones(t *testing.T) {
+	c := newCollection[string, int](InsertionOrder)
+	for i := 0; i < 100; i++ {
+		c.set(fmt.Sprint(i), i)
+	}
+	for i := 0; i < 90; i++ {
+		c.delete(fmt.Sprint(i))
+	}
+	if len(c.entries) > 2*c.len()+1 {
+		t.Fatalf("delete() expected tombstones to be compacted, got: %d entries for %d items", len(c.entries), c.len())
+	}
+	if keys := collectKeys(c); len(keys) != 10 || keys[0] != "90" {
+		t.Fatalf("all() expected keys 90 to 99, got: %v", keys)
+	}
+}
+
+func TestCollection_MutationWhileIterating(t *testing.T) {
+	for _, ordering := range []Ordering{InsertionOrder, SortedByID} {
+		c := newCollection[string, int](ordering)
+		for i := 0; i < 10; i++ {
+			c.set(fmt.Sprint(i), i)
+		}
+		var keys []string
+		for k := range c.all() {
+			keys = append(keys, k)
+			if k == "4" {
+				for i := 0; i < 8; i++ {
+					c.delete(fmt.Sprint(i))
+				}
+				c.set("10", 10)
+			}
+		}
+		expected := []string{"0", "1", "2", "3", "4", "8", "9", "10"}
+		if ordering == SortedByID {
+			expected = []string{"0", "1", "2", "3", "4", "8", "9"}
+		}
+		if !slices.Equal(keys, expected) {
+			t.Fatalf("all() expected %v with ordering %d, got: %v", expected, ordering, keys)
+		}
+	}
+}
+
+func TestCollection_Nil(t *testing.T) {
+	var c *collection[string, int]
+	if c.len() != 0 || len(collectKeys(c)) != 0 {
+		t.Fatalf("nil collection expected to be empty")
+	}
+	if _, ok := c.get("a"); ok {
+		t.Fatalf("get() expected no item on nil collection")
+	}
+}
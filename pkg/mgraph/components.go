@@ -0,0 +1,132 @@
+package mgraph
+
+import (
+	"slices"
+	"strconv"
+)
+
+type Components struct {
+	Membership map[VertexID]int
+	Components [][]VertexID
+}
+
+func (c *Components) add(members []VertexID) {
+	for _, id := range members {
+		c.Membership[id] = len(c.Components)
+	}
+	c.Components = append(c.Components, members)
+}
+
+func WeaklyConnectedComponents[V, E any](g TypedView[V, E]) Components {
+	c := Components{Membership: make(map[VertexID]int, g.Order())}
+	g.ForEachVertex(func(v TypedVertex[V, E]) bool {
+		if _, ok := c.Membership[v.Id()]; ok {
+			return true
+		}
+		var members []VertexID
+		_ = BFS(g, v.Id(), Visitor[V, E]{
+			DiscoverVertex: func(v TypedVertex[V, E], _ int) bool {
+				members = append(members, v.Id())
+				return true
+			},
+		}, TraversalOptions{Direction: FollowBoth})
+		c.add(members)
+		return true
+	})
+	return c
+}
+
+// StronglyConnectedComponents uses Tarjan's algorithm. Components are listed in
+// topological order of the condensation, so edges between components always go
+// from a lower to a higher index.
+func StronglyConnectedComponents[V, E any](g TypedView[V, E]) Components {
+	type frame struct {
+		id    VertexID
+		edges []TypedEdge[V, E]
+	}
+	index := make(map[VertexID]int, g.Order())
+	low := make(map[VertexID]int, g.Order())
+	onStack := map[VertexID]bool{}
+	var stack []VertexID
+	var found [][]VertexID
+	g.ForEachVertex(func(root TypedVertex[V, E]) bool {
+		if _, ok := index[root.Id()]; ok {
+			return true
+		}
+		visit := func(v TypedVertex[V, E]) *frame {
+			index[v.Id()] = len(index)
+			low[v.Id()] = index[v.Id()]
+			stack = append(stack, v.Id())
+			onStack[v.Id()] = true
+			return &frame{id: v.Id(), edges: v.Outgoing()}
+		}
+		frames := []*frame{visit(root)}
+		for len(frames) > 0 {
+			f := frames[len(frames)-1]
+			if len(f.edges) > 0 {
+				e := f.edges[0]
+				f.edges = f.edges[1:]
+				to := opposite(e, f.id)
+				if _, ok := index[to]; !ok {
+					if next := g.Vertex(to); next != nil {
+						frames = append(frames, visit(next))
+					}
+				} else if onStack[to] {
+					low[f.id] = min(low[f.id], index[to])
+				}
+				continue
+			}
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].id
+				low[parent] = min(low[parent], low[f.id])
+			}
+			if low[f.id] == index[f.id] {
+				var members []VertexID
+				for {
+					id := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[id] = false
+					members = append(members, id)
+					if id == f.id {
+						break
+					}
+				}
+				found = append(found, members)
+			}
+		}
+		return true
+	})
+	slices.Reverse(found)
+	c := Components{Membership: make(map[VertexID]int, g.Order())}
+	for _, members := range found {
+		c.add(members)
+	}
+	return c
+}
+
+// Condensation contracts every strongly connected component into a single vertex,
+// identified by its component index and storing its members. Edges between
+// different components are kept with their original ids and store the original edge.
+func Condensation[V, E any](g TypedView[V, E]) (TypedGraph[[]VertexID, TypedEdge[V, E]], Components) {
+	c := StronglyConnectedComponents(g)
+	dag := NewTyped[[]VertexID, TypedEdge[V, E]]()
+	for i, members := range c.Components {
+		v, _ := dag.AddVertex(componentID(i))
+		v.StoreData(members)
+	}
+	g.ForEachEdge(func(e TypedEdge[V, E]) bool {
+		from, okFrom := c.Membership[e.From()]
+		to, okTo := c.Membership[e.To()]
+		if okFrom && okTo && from != to {
+			ce, _ := dag.AddEdge(e.Id(), componentID(from), componentID(to))
+			ce.StoreData(e)
+		}
+		return true
+	})
+	return dag, c
+}
+
+func componentID(i int) VertexID {
+	return VertexID(strconv.Itoa(i))
+}
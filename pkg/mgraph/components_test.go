@@ -0,0 +1,90 @@
+package mgraph
+
+import "testing"
+
+func newComponentsGraph() Graph {
+	g := New()
+	for _, id := range []VertexID{"node1", "node2", "node3", "node4", "node5", "node6", "node7"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	_, _ = g.AddEdge("edge2", "node2", "node3")
+	_, _ = g.AddEdge("edge3", "node3", "node1")
+	_, _ = g.AddEdge("edge4", "node3", "node4")
+	_, _ = g.AddEdge("edge5", "node4", "node5")
+	_, _ = g.AddEdge("edge6", "node5", "node4")
+	_, _ = g.AddEdge("edge7", "node2", "node5")
+	_, _ = g.AddEdge("edge8", "node6", "node6")
+	return g
+}
+
+func TestWeaklyConnectedComponents(t *testing.T) {
+	g := newComponentsGraph()
+	c := WeaklyConnectedComponents(g)
+	if len(c.Components) != 3 {
+		t.Fatalf("WeaklyConnectedComponents() expected 3 components, got: %v", c.Components)
+	}
+	if c.Membership["node1"] != c.Membership["node5"] {
+		t.Fatalf("WeaklyConnectedComponents() expected node1 and node5 in the same component, got: %v", c.Membership)
+	}
+	if c.Membership["node6"] == c.Membership["node7"] {
+		t.Fatalf("WeaklyConnectedComponents() expected node6 and node7 in different components, got: %v", c.Membership)
+	}
+	if len(c.Components[c.Membership["node1"]]) != 5 {
+		t.Fatalf("WeaklyConnectedComponents() expected component with 5 vertices, got: %v", c.Components[c.Membership["node1"]])
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := newComponentsGraph()
+	c := StronglyConnectedComponents(g)
+	if len(c.Components) != 4 {
+		t.Fatalf("StronglyConnectedComponents() expected 4 components, got: %v", c.Components)
+	}
+	if c.Membership["node1"] != c.Membership["node2"] || c.Membership["node1"] != c.Membership["node3"] {
+		t.Fatalf("StronglyConnectedComponents() expected node1, node2 and node3 together, got: %v", c.Membership)
+	}
+	if c.Membership["node4"] != c.Membership["node5"] {
+		t.Fatalf("StronglyConnectedComponents() expected node4 and node5 together, got: %v", c.Membership)
+	}
+	if c.Membership["node1"] >= c.Membership["node4"] {
+		t.Fatalf("StronglyConnectedComponents() expected components in topological order, got: %v", c.Membership)
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	g := newComponentsGraph()
+	dag, c := Condensation(g)
+	if dag.Order() != len(c.Components) {
+		t.Fatalf("Condensation() expected %d vertices, got: %d", len(c.Components), dag.Order())
+	}
+	if dag.Size() != 2 {
+		t.Fatalf("Condensation() expected 2 edges, got: %v", dag.Edges())
+	}
+	e := dag.Edge("edge4")
+	if e == nil || e.Data().Id() != "edge4" {
+		t.Fatalf("Condensation() expected to keep edge4, got: %v", e)
+	}
+	if len(e.Tail().Data()) != 3 {
+		t.Fatalf("Condensation() expected tail with 3 members, got: %v", e.Tail().Data())
+	}
+	if len(dag.EdgesBetween(e.From(), e.To())) != 2 {
+		t.Fatalf("Condensation() expected 2 parallel edges between components, got: %v", dag.EdgesBetween(e.From(), e.To()))
+	}
+}
+
+func TestStronglyConnectedComponents_WithUndirectedEdges(t *testing.T) {
+	g := New(WithDirectedness(Mixed))
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddVertex("node3")
+	_, _ = g.AddEdge("edge1", "node1", "node2", UndirectedEdge())
+	_, _ = g.AddEdge("edge2", "node2", "node3")
+	c := StronglyConnectedComponents(g)
+	if len(c.Components) != 2 || c.Membership["node1"] != c.Membership["node2"] {
+		t.Fatalf("StronglyConnectedComponents() expected node1 and node2 together, got: %v", c.Components)
+	}
+	if _, err := TopologicalSort(g); err != nil {
+		t.Fatalf("TopologicalSort() expected to ignore undirected edges, got: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package mgraph
+
+import "sync"
+
+// NewConcurrent returns a graph that is safe for concurrent use. Every operation,
+// including the cascades of RemoveVertex, runs atomically under a single
+// readers-writer lock shared by the graph, its vertices and its edges, and
+// enumerations return snapshots taken while holding it.
+func NewConcurrent(opts ...Option) Graph {
+	return NewTypedConcurrent[any, any](opts...)
+}
+
+func NewTypedConcurrent[V, E any](opts ...Option) TypedGraph[V, E] {
+	return newGraph[V, E](&rwLock{}, newProperties(opts))
+}
+
+type locker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+	concurrent() bool
+	new() locker
+}
+
+type noLock struct{}
+
+func (noLock) Lock()            {}
+func (noLock) Unlock()          {}
+func (noLock) RLock()           {}
+func (noLock) RUnlock()         {}
+func (noLock) concurrent() bool { return false }
+func (noLock) new() locker      { return noLock{} }
+
+type rwLock struct {
+	sync.RWMutex
+}
+
+func (*rwLock) concurrent() bool { return true }
+func (*rwLock) new() locker      { return &rwLock{} }
@@ -0,0 +1,103 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestNewConcurrent(t *testing.T) {
+	g := NewConcurrent()
+	if g == nil {
+		t.Fatalf("NewConcurrent() returned nil")
+	}
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	_, err := g.AddEdge("edge1", "node1", "node2")
+	if !errors.Is(err, ErrEdgeAlreadyAdded) {
+		t.Fatalf("AddEdge() expected error ErrEdgeAlreadyAdded, got: %v", err)
+	}
+	g.RemoveVertex("node1")
+	if g.Edge("edge1") != nil {
+		t.Fatalf("RemoveVertex() expected to remove edge, got: %v", g.Edge("edge1"))
+	}
+}
+
+func TestConcurrentGraph_ParallelReadsAndWrites(t *testing.T) {
+	g := NewTypedConcurrent[int, int]()
+	_, _ = g.AddVertex("hub")
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				id := VertexID(fmt.Sprintf("node%d-%d", w, i))
+				v, err := g.AddVertex(id)
+				if err != nil {
+					t.Errorf("AddVertex() expected no error, got: %v", err)
+					return
+				}
+				v.StoreData(i)
+				e, _ := g.AddEdge(EdgeID(id), "hub", id)
+				e.StoreData(i)
+				if i%2 == 0 {
+					g.RemoveVertex(id)
+				}
+			}
+		}(w)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				hub := g.Vertex("hub")
+				for _, e := range hub.Outgoing() {
+					_ = e.Data()
+					_ = e.Head()
+				}
+				_ = hub.Degree()
+				_ = g.Degree()
+				g.ForEachVertex(func(v TypedVertex[int, int]) bool {
+					_ = v.Data()
+					_ = v.Incoming()
+					return true
+				})
+				_ = g.IsConsistent()
+			}
+		}()
+	}
+	wg.Wait()
+	if g.Order() != 401 {
+		t.Fatalf("Order() expected 401, got: %d", g.Order())
+	}
+	if g.Size() != 400 || len(g.Vertex("hub").Outgoing()) != 400 {
+		t.Fatalf("Size() expected 400, got: %d", g.Size())
+	}
+	if !g.IsConsistent() {
+		t.Fatalf("IsConsistent() expected to return true, got: %v", g.IsConsistent())
+	}
+}
+
+func TestConcurrentGraph_AlgorithmsAndMutationsInCallbacks(t *testing.T) {
+	g := NewConcurrent()
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	g.ForEachVertex(func(v Vertex) bool {
+		_, _ = g.AddVertex(v.Id() + "-copy")
+		return true
+	})
+	if g.Order() != 4 {
+		t.Fatalf("ForEachVertex() expected callbacks to be able to mutate the graph, got: %d vertices", g.Order())
+	}
+	_ = g.EnsureAcyclicity(true)
+	_, err := g.AddEdge("edge2", "node2", "node1")
+	if !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle, got: %v", err)
+	}
+	if _, err := TopologicalSort(g); err != nil {
+		t.Fatalf("TopologicalSort() expected no error, got: %v", err)
+	}
+}
@@ -1,96 +1,212 @@
 package mgraph
 
+import (
+	"fmt"
+	"time"
+)
+
 type EdgeID string
 
-type Edge interface {
+type Edge = TypedEdge[any, any]
+
+type TypedEdge[V, E any] interface {
 	Id() EdgeID
+	Label() string
+	SetProperty(property string, value any) error
+	RemoveProperty(property string) error
+	Property(property string) (any, bool)
+	Properties() map[string]any
+	Validity() Interval
+	SetValidity(from, to time.Time) error
 	From() VertexID
 	To() VertexID
-	StoreData(data any)
-	Data() any
-	Tail() Vertex
-	Head() Vertex
-	Endpoints() [2]Vertex
-	IsIncident(vertex Vertex) bool
-	IsInverted(edge Edge) bool
-	IsParallel(edge Edge) bool
+	StoreData(data E)
+	Data() E
+	Tail() TypedVertex[V, E]
+	Head() TypedVertex[V, E]
+	Endpoints() [2]TypedVertex[V, E]
+	IsIncident(vertex TypedVertex[V, E]) bool
+	IsInverted(edge TypedEdge[V, E]) bool
+	IsParallel(edge TypedEdge[V, E]) bool
 	IsLoop() bool
-	Graph() Graph
-}
-
-func newEdge(id EdgeID, from, to VertexID, graph *graph) *edge {
-	return &edge{
-		id:    id,
-		from:  from,
-		to:    to,
-		graph: graph,
+	IsDirected() bool
+	Graph() TypedGraph[V, E]
+}
+
+func newEdge[V, E any](id EdgeID, from, to VertexID, label string, directed bool, seq uint64, graph *graph[V, E]) *edge[V, E] {
+	return &edge[V, E]{
+		id:       id,
+		label:    label,
+		seq:      seq,
+		from:     from,
+		to:       to,
+		directed: directed,
+		mu:       graph.mu,
+		txMu:     graph.txMu,
+		hub:      graph.hub,
+		graph:    graph,
 	}
 }
 
-type edge struct {
-	id    EdgeID
-	from  VertexID
-	to    VertexID
-	data  any
-	graph *graph
+type edge[V, E any] struct {
+	id       EdgeID
+	label    string
+	from     VertexID
+	to       VertexID
+	directed bool
+	seq      uint64
+	props    map[string]any
+	validity Interval
+	mu       locker
+	txMu     locker
+	hub      *hub
+	data     E
+	graph    *graph[V, E]
 }
 
-func (e *edge) Id() EdgeID {
+func (e *edge[V, E]) Id() EdgeID {
 	return e.id
 }
 
-func (e *edge) From() VertexID {
+func (e *edge[V, E]) Label() string {
+	return e.label
+}
+
+func (e *edge[V, E]) From() VertexID {
 	return e.from
 }
 
-func (e *edge) To() VertexID {
+func (e *edge[V, E]) To() VertexID {
 	return e.to
 }
 
-func (e *edge) StoreData(data any) {
+func (e *edge[V, E]) Validity() Interval {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.validity
+}
+
+// SetValidity sets the interval of time [from, to) during which the edge holds.
+func (e *edge[V, E]) SetValidity(from, to time.Time) error {
+	return e.setValidityBy(nil, from, to)
+}
+
+func (e *edge[V, E]) setValidityBy(t *tx[V, E], from, to time.Time) error {
+	defer e.hub.publish()
+	unlock, err := lock(e.mu, e.txMu, t, func() { e.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while setting validity of edge '%s': %w", e.id, err)
+	}
+	defer unlock()
+	e.graph.seal()
+	e.setValidity(Interval{From: from, To: to})
+	return nil
+}
+
+func (e *edge[V, E]) setValidity(validity Interval) {
+	if e.graph != nil {
+		old := e.validity
+		e.graph.record(func() { e.setValidity(old) })
+		e.graph.hub.emit(Event{Kind: EdgeValidityChanged, Edge: e.id, From: e.from, To: e.to})
+	}
+	e.validity = validity
+	if e.graph != nil {
+		e.graph.mirrorEdge(e)
+	}
+}
+
+func (e *edge[V, E]) StoreData(data E) {
+	e.storeDataBy(nil, data)
+}
+
+func (e *edge[V, E]) storeDataBy(t *tx[V, E], data E) {
+	defer e.hub.publish()
+	unlock, err := lock(e.mu, e.txMu, t, func() { e.graph.commit() })
+	if err != nil {
+		panic(fmt.Errorf("error while storing data of edge '%s': %w", e.id, err))
+	}
+	defer unlock()
+	e.graph.seal()
+	e.storeData(data)
+}
+
+func (e *edge[V, E]) storeData(data E) {
+	if e.graph != nil {
+		old := e.data
+		e.graph.record(func() { e.storeData(old) })
+		e.graph.hub.emit(Event{Kind: EdgeDataChanged, Edge: e.id, From: e.from, To: e.to})
+	}
 	e.data = data
+	if e.graph != nil {
+		e.graph.mirrorEdge(e)
+	}
 }
 
-func (e *edge) Data() any {
+func (e *edge[V, E]) Data() E {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	return e.data
 }
 
-func (e *edge) Tail() Vertex {
-	return e.graph.Vertex(e.from)
+func (e *edge[V, E]) Tail() TypedVertex[V, E] {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.endpoint(e.from)
+}
+
+func (e *edge[V, E]) Head() TypedVertex[V, E] {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.endpoint(e.to)
 }
 
-func (e *edge) Head() Vertex {
-	return e.graph.Vertex(e.to)
+func (e *edge[V, E]) Endpoints() [2]TypedVertex[V, E] {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return [2]TypedVertex[V, E]{e.endpoint(e.from), e.endpoint(e.to)}
 }
 
-func (e *edge) Endpoints() [2]Vertex {
-	return [2]Vertex{e.graph.Vertex(e.from), e.graph.Vertex(e.to)}
+func (e *edge[V, E]) endpoint(id VertexID) TypedVertex[V, E] {
+	if e.graph == nil {
+		return nil
+	}
+	return e.graph.vertex(id)
 }
 
-func (e *edge) IsIncident(vertex Vertex) bool {
+func (e *edge[V, E]) IsIncident(vertex TypedVertex[V, E]) bool {
 	return e.from == vertex.Id() || e.to == vertex.Id()
 }
 
-func (e *edge) IsInverted(edge Edge) bool {
+func (e *edge[V, E]) IsInverted(edge TypedEdge[V, E]) bool {
 	return e.from == edge.To() && e.to == edge.From()
 }
 
-func (e *edge) IsParallel(edge Edge) bool {
+func (e *edge[V, E]) IsParallel(edge TypedEdge[V, E]) bool {
+	if !e.directed || !edge.IsDirected() {
+		return e.from == edge.From() && e.to == edge.To() || e.from == edge.To() && e.to == edge.From()
+	}
 	return e.from == edge.From() && e.to == edge.To()
 }
 
-func (e *edge) IsLoop() bool {
+func (e *edge[V, E]) IsLoop() bool {
 	return e.from == e.to
 }
 
-func (e *edge) Graph() Graph {
+func (e *edge[V, E]) IsDirected() bool {
+	return e.directed
+}
+
+func (e *edge[V, E]) Graph() TypedGraph[V, E] {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 	if e.graph != nil {
 		return e.graph
 	}
 	return nil
 }
 
-func (e *edge) onRemove() {
-	e.data = nil
+func (e *edge[V, E]) onRemove() {
+	var zero E
+	e.data = zero
 	e.graph = nil
 }
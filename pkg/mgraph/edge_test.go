@@ -39,6 +39,23 @@ func TestEdge_Data(t *testing.T) {
 	}
 }
 
+func TestTypedEdge_Data(t *testing.T) {
+	type viewed struct {
+		rating byte
+	}
+	g := NewTyped[string, viewed]()
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	e, _ := g.AddEdge("edge1", "node1", "node2")
+	e.StoreData(viewed{rating: 4})
+	if e.Data().rating != 4 {
+		t.Errorf("Data() expected 4, got %d", e.Data().rating)
+	}
+	if g.EdgesBetween("node1", "node2")[0].Data().rating != 4 {
+		t.Errorf("Data() expected 4 through EdgesBetween(), got %v", g.EdgesBetween("node1", "node2")[0].Data())
+	}
+}
+
 func TestEdge_TailAndHeadAndEndpoints_WithConsistentGraph(t *testing.T) {
 	g := New()
 	v1, _ := g.AddVertex("node1")
@@ -151,3 +168,17 @@ func TestEdge_Graph(t *testing.T) {
 		t.Errorf("Graph() expected %v, got %v", g, e.Graph())
 	}
 }
+
+func TestEdge_IsDirectedAndIsParallel_OnUndirectedGraph(t *testing.T) {
+	g := New(WithDirectedness(Undirected))
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	e1, _ := g.AddEdge("edge1", "node1", "node2")
+	e2, _ := g.AddEdge("edge2", "node2", "node1")
+	if e1.IsDirected() {
+		t.Errorf("IsDirected() expected false, got true")
+	}
+	if !e1.IsParallel(e2) || !e2.IsParallel(e1) {
+		t.Errorf("IsParallel() expected true, got false")
+	}
+}
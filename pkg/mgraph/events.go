@@ -0,0 +1,130 @@
+package mgraph
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+type EventKind int
+
+const (
+	VertexAdded EventKind = iota
+	VertexRemoved
+	VertexDataChanged
+	VertexPropertyChanged
+	EdgeAdded
+	EdgeRemoved
+	EdgeDataChanged
+	EdgePropertyChanged
+	VertexValidityChanged
+	EdgeValidityChanged
+)
+
+// Event describes a mutation of a graph. Seq numbers the mutations of the graph
+// from 1 in the order they were applied.
+type Event struct {
+	Seq    uint64
+	Kind   EventKind
+	Vertex VertexID
+	Edge   EdgeID
+	// From and To are the endpoints of the edge of edge events.
+	From     VertexID
+	To       VertexID
+	Property string
+	// Cascade marks the edge removals done by RemoveVertex.
+	Cascade bool
+}
+
+// Subscribe calls the given function for every later mutation of the graph until
+// the returned function is called. Events are delivered after the mutation is
+// done and the graph unlocked, so the function may use the graph, one at a time
+// and by increasing Seq: the cascaded edge removals of a vertex come before its
+// own removal. A rolled back transaction emits the events undoing its changes.
+// On concurrent graphs, the events of a mutation may be delivered by another
+// goroutine, after the mutation returned.
+func (g *graph[V, E]) Subscribe(fn func(Event)) (cancel func()) {
+	return g.hub.subscribe(fn)
+}
+
+// hub queues the events of a graph and delivers them to its subscribers.
+type hub struct {
+	mu          sync.Mutex
+	seq         uint64
+	subscribers []*subscriber
+	pending     []delivery
+	publishing  bool
+}
+
+type subscriber struct {
+	fn        func(Event)
+	cancelled atomic.Bool
+}
+
+type delivery struct {
+	event       Event
+	subscribers []*subscriber
+}
+
+func (h *hub) subscribe(fn func(Event)) func() {
+	s := &subscriber{fn: fn}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers = append(slices.Clip(h.subscribers), s)
+	return func() {
+		s.cancelled.Store(true)
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.subscribers = slices.DeleteFunc(slices.Clone(h.subscribers), func(other *subscriber) bool { return other == s })
+	}
+}
+
+// emit queues an event; it is called while the graph is locked, so events are
+// numbered in the order mutations are applied.
+func (h *hub) emit(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	if len(h.subscribers) > 0 {
+		event.Seq = h.seq
+		h.pending = append(h.pending, delivery{event: event, subscribers: h.subscribers})
+	}
+}
+
+// publish delivers the queued events once the graph is unlocked. When events are
+// already being delivered, by this goroutine from a subscriber or by another one,
+// it leaves the new ones to that delivery, which keeps them in order.
+func (h *hub) publish() {
+	h.mu.Lock()
+	if h.publishing || len(h.pending) == 0 {
+		h.mu.Unlock()
+		return
+	}
+	h.publishing = true
+	h.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			h.mu.Lock()
+			h.publishing = false
+			h.mu.Unlock()
+			panic(r)
+		}
+	}()
+	for {
+		h.mu.Lock()
+		if len(h.pending) == 0 {
+			h.pending = nil
+			h.publishing = false
+			h.mu.Unlock()
+			return
+		}
+		d := h.pending[0]
+		h.pending = h.pending[1:]
+		h.mu.Unlock()
+		for _, s := range d.subscribers {
+			if !s.cancelled.Load() {
+				s.fn(d.event)
+			}
+		}
+	}
+}
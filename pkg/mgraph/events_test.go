@@ -0,0 +1,125 @@
+package mgraph
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func describeEvents(events []Event) (described []string) {
+	for _, e := range events {
+		var d string
+		switch e.Kind {
+		case VertexAdded:
+			d = "+" + string(e.Vertex)
+		case VertexRemoved:
+			d = "-" + string(e.Vertex)
+		case VertexDataChanged:
+			d = "~" + string(e.Vertex)
+		case VertexPropertyChanged:
+			d = fmt.Sprintf("~%s.%s", e.Vertex, e.Property)
+		case EdgeAdded:
+			d = fmt.Sprintf("+%s(%s->%s)", e.Edge, e.From, e.To)
+		case EdgeRemoved:
+			d = fmt.Sprintf("-%s(%s->%s)", e.Edge, e.From, e.To)
+			if e.Cascade {
+				d += "!"
+			}
+		case EdgeDataChanged:
+			d = "~" + string(e.Edge)
+		case EdgePropertyChanged:
+			d = fmt.Sprintf("~%s.%s", e.Edge, e.Property)
+		case VertexValidityChanged:
+			d = "@" + string(e.Vertex)
+		case EdgeValidityChanged:
+			d = "@" + string(e.Edge)
+		}
+		described = append(described, d)
+	}
+	return
+}
+
+func TestGraph_Subscribe(t *testing.T) {
+	g := New(WithOrdering(InsertionOrder))
+	var events []Event
+	cancel := g.Subscribe(func(e Event) { events = append(events, e) })
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddEdge("e1", "a", "b")
+	_, _ = g.AddEdge("e2", "b", "a")
+	g.Vertex("a").StoreData(1)
+	_ = g.Edge("e1").SetProperty("weight", 2)
+	g.Edge("e2").StoreData(3)
+	g.RemoveVertex("a")
+	cancel()
+	_, _ = g.AddVertex("c")
+	expected := []string{"+a", "+b", "+e1(a->b)", "+e2(b->a)", "~a", "~e1.weight", "~e2", "-e1(a->b)!", "-e2(b->a)!", "-a"}
+	if described := describeEvents(events); !slices.Equal(described, expected) {
+		t.Fatalf("Subscribe() expected events %v, got: %v", expected, described)
+	}
+	for i, e := range events {
+		if e.Seq != uint64(i+1) {
+			t.Fatalf("Subscribe() expected event %d to have seq %d, got: %d", i, i+1, e.Seq)
+		}
+	}
+}
+
+func TestGraph_Subscribe_RollbackEmitsUndoingEvents(t *testing.T) {
+	g := New()
+	_, _ = g.AddVertex("a")
+	var events []Event
+	g.Subscribe(func(e Event) { events = append(events, e) })
+	tx := g.Begin()
+	tx.Vertex("a").StoreData(1)
+	tx.RemoveVertex("a")
+	_ = tx.Rollback()
+	expected := []string{"~a", "-a", "+a", "~a"}
+	if described := describeEvents(events); !slices.Equal(described, expected) {
+		t.Fatalf("Subscribe() expected events %v, got: %v", expected, described)
+	}
+}
+
+func TestGraph_Subscribe_SubscriberMayUseGraph(t *testing.T) {
+	g := NewConcurrent()
+	var described []string
+	g.Subscribe(func(e Event) {
+		if e.Kind == VertexAdded {
+			_, _ = g.AddEdge(EdgeID(e.Vertex), e.Vertex, e.Vertex)
+		}
+		described = append(described, describeEvents([]Event{e})...)
+	})
+	_, _ = g.AddVertex("a")
+	expected := []string{"+a", "+a(a->a)"}
+	if !slices.Equal(described, expected) {
+		t.Fatalf("Subscribe() expected events %v, got: %v", expected, described)
+	}
+}
+
+func TestGraph_Subscribe_Concurrent(t *testing.T) {
+	g := NewConcurrent()
+	var mu sync.Mutex
+	var seqs []uint64
+	g.Subscribe(func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		seqs = append(seqs, e.Seq)
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				v, _ := g.AddVertex(VertexID(fmt.Sprint(i, "-", j)))
+				v.StoreData(j)
+			}
+		}()
+	}
+	wg.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seqs) != 800 || !slices.IsSorted(seqs) {
+		t.Fatalf("Subscribe() expected 800 events in seq order, got %d events", len(seqs))
+	}
+}
@@ -0,0 +1,280 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrNegativeCapacity = errors.New("negative edge capacity")
+	ErrSourceIsSink     = errors.New("source and sink are the same vertex")
+)
+
+// flowTolerance is the residual capacity under which an arc is saturated, which
+// absorbs rounding errors on float capacities.
+const flowTolerance = 1e-9
+
+// Capacity returns how much can flow through an edge. A nil Capacity gives every
+// edge a capacity of 1.
+type Capacity[V, E any] func(e TypedEdge[V, E]) float64
+
+func (c Capacity[V, E]) capacity(e TypedEdge[V, E]) float64 {
+	if c == nil {
+		return 1
+	}
+	return c(e)
+}
+
+// Flow is a maximum flow between two vertices, along with a minimum cut.
+type Flow struct {
+	Value float64
+	// Edges maps every edge to the flow through it, from its tail to its head. The
+	// flow through an undirected edge is negative when it goes from head to tail.
+	Edges map[EdgeID]float64
+	// SourceSide lists the vertices still reachable from the source in the residual
+	// graph, and SinkSide the other ones, both in the graph order.
+	SourceSide []VertexID
+	SinkSide   []VertexID
+	// Cut lists the edges from the source side to the sink side, whose capacities
+	// add up to the flow value.
+	Cut []EdgeID
+}
+
+// EdmondsKarp computes a maximum flow by augmenting along shortest paths. Parallel
+// edges add up their capacities and undirected edges carry flow either way.
+func EdmondsKarp[V, E any](g TypedView[V, E], source, sink VertexID, capacity Capacity[V, E]) (Flow, error) {
+	n, err := newFlowNetwork(g, source, sink, capacity)
+	if err != nil {
+		return Flow{}, err
+	}
+	for {
+		via := n.shortestAugmentingPath()
+		if via[n.sink] < 0 {
+			break
+		}
+		bottleneck := math.Inf(1)
+		for v := n.sink; v != n.source; v = n.arcs[via[v]^1].to {
+			bottleneck = min(bottleneck, n.residual(via[v]))
+		}
+		for v := n.sink; v != n.source; v = n.arcs[via[v]^1].to {
+			n.push(via[v], bottleneck)
+		}
+	}
+	return n.result(g), nil
+}
+
+// Dinic computes the same maximum flow as EdmondsKarp with blocking flows over a
+// level graph, which is faster on large networks.
+func Dinic[V, E any](g TypedView[V, E], source, sink VertexID, capacity Capacity[V, E]) (Flow, error) {
+	n, err := newFlowNetwork(g, source, sink, capacity)
+	if err != nil {
+		return Flow{}, err
+	}
+	for n.levelGraph() {
+		next := make([]int, len(n.adj))
+		for n.blockingFlow(n.source, math.Inf(1), next) > 0 {
+		}
+	}
+	return n.result(g), nil
+}
+
+// flowNetwork merges the edges between each pair of vertices into a pair of
+// opposite arcs, a and a^1, whose flows are opposite.
+type flowNetwork[V, E any] struct {
+	ids          []VertexID
+	index        map[VertexID]int
+	adj          [][]int
+	arcs         []flowArc
+	pairs        map[[2]int]int
+	edges        [][]pairedEdge[V, E]
+	source, sink int
+	level        []int
+}
+
+type flowArc struct {
+	to       int
+	capacity float64
+	flow     float64
+}
+
+type pairedEdge[V, E any] struct {
+	e        TypedEdge[V, E]
+	capacity float64
+}
+
+func newFlowNetwork[V, E any](g TypedView[V, E], source, sink VertexID, capacity Capacity[V, E]) (*flowNetwork[V, E], error) {
+	n := &flowNetwork[V, E]{index: map[VertexID]int{}, pairs: map[[2]int]int{}}
+	for v := range g.AllVertices() {
+		n.index[v.Id()] = len(n.ids)
+		n.ids = append(n.ids, v.Id())
+	}
+	n.adj = make([][]int, len(n.ids))
+	for _, id := range [2]VertexID{source, sink} {
+		if _, ok := n.index[id]; !ok {
+			return nil, fmt.Errorf("error while computing flow from '%s' to '%s': %w", source, sink, fmt.Errorf("vertex '%s' does not exists: %w", id, ErrVertexDoesNotExists))
+		}
+	}
+	if source == sink {
+		return nil, fmt.Errorf("error while computing flow from '%s' to '%s': %w", source, sink, ErrSourceIsSink)
+	}
+	n.source, n.sink = n.index[source], n.index[sink]
+	for e := range g.AllEdges() {
+		from, okFrom := n.index[e.From()]
+		to, okTo := n.index[e.To()]
+		if !okFrom || !okTo || from == to {
+			continue
+		}
+		c := capacity.capacity(e)
+		if c < 0 {
+			return nil, fmt.Errorf("error while computing flow from '%s' to '%s': edge '%s': %w", source, sink, e.Id(), ErrNegativeCapacity)
+		}
+		a := n.arc(from, to)
+		n.arcs[a].capacity += c
+		if !e.IsDirected() {
+			n.arcs[a^1].capacity += c
+		}
+		n.edges[a/2] = append(n.edges[a/2], pairedEdge[V, E]{e: e, capacity: c})
+	}
+	return n, nil
+}
+
+// arc returns the arc from a vertex to another, adding it along with its opposite
+// arc when missing.
+func (n *flowNetwork[V, E]) arc(from, to int) int {
+	if a, ok := n.pairs[[2]int{from, to}]; ok {
+		return a
+	}
+	if a, ok := n.pairs[[2]int{to, from}]; ok {
+		return a ^ 1
+	}
+	a := len(n.arcs)
+	n.pairs[[2]int{from, to}] = a
+	n.arcs = append(n.arcs, flowArc{to: to}, flowArc{to: from})
+	n.adj[from] = append(n.adj[from], a)
+	n.adj[to] = append(n.adj[to], a^1)
+	n.edges = append(n.edges, nil)
+	return a
+}
+
+func (n *flowNetwork[V, E]) residual(a int) float64 {
+	return n.arcs[a].capacity - n.arcs[a].flow
+}
+
+func (n *flowNetwork[V, E]) push(a int, amount float64) {
+	n.arcs[a].flow += amount
+	n.arcs[a^1].flow -= amount
+}
+
+// shortestAugmentingPath returns, for every vertex reached by a BFS over the
+// residual graph, the arc it was reached through, or -1.
+func (n *flowNetwork[V, E]) shortestAugmentingPath() []int {
+	via := make([]int, len(n.ids))
+	for i := range via {
+		via[i] = -1
+	}
+	queue := []int{n.source}
+	for len(queue) > 0 && via[n.sink] < 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, a := range n.adj[v] {
+			to := n.arcs[a].to
+			if to != n.source && via[to] < 0 && n.residual(a) > flowTolerance {
+				via[to] = a
+				queue = append(queue, to)
+			}
+		}
+	}
+	return via
+}
+
+// levelGraph computes the BFS distance of every vertex from the source over the
+// residual graph, and reports whether the sink is reachable.
+func (n *flowNetwork[V, E]) levelGraph() bool {
+	n.level = make([]int, len(n.ids))
+	for i := range n.level {
+		n.level[i] = -1
+	}
+	n.level[n.source] = 0
+	queue := []int{n.source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, a := range n.adj[v] {
+			if to := n.arcs[a].to; n.level[to] < 0 && n.residual(a) > flowTolerance {
+				n.level[to] = n.level[v] + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+	return n.level[n.sink] >= 0
+}
+
+// blockingFlow pushes up to limit along one path of the level graph, skipping the
+// arcs of each vertex that are already known to be blocked.
+func (n *flowNetwork[V, E]) blockingFlow(v int, limit float64, next []int) float64 {
+	if v == n.sink {
+		return limit
+	}
+	for ; next[v] < len(n.adj[v]); next[v]++ {
+		a := n.adj[v][next[v]]
+		to := n.arcs[a].to
+		if n.level[to] != n.level[v]+1 || n.residual(a) <= flowTolerance {
+			continue
+		}
+		if pushed := n.blockingFlow(to, min(limit, n.residual(a)), next); pushed > 0 {
+			n.push(a, pushed)
+			return pushed
+		}
+	}
+	return 0
+}
+
+func (n *flowNetwork[V, E]) result(g TypedView[V, E]) Flow {
+	f := Flow{Edges: map[EdgeID]float64{}, SourceSide: []VertexID{}, SinkSide: []VertexID{}, Cut: []EdgeID{}}
+	for _, a := range n.adj[n.source] {
+		f.Value += n.arcs[a].flow
+	}
+	for pair, edges := range n.edges {
+		flow, forward := n.arcs[2*pair].flow, n.arcs[2*pair+1].to
+		if flow < 0 {
+			flow, forward = -flow, n.arcs[2*pair].to
+		}
+		for _, p := range edges {
+			f.Edges[p.e.Id()] = 0
+			tail := n.index[p.e.From()] == forward
+			if flow <= 0 || !tail && p.e.IsDirected() {
+				continue
+			}
+			amount := min(flow, p.capacity)
+			flow -= amount
+			if !tail {
+				amount = -amount
+			}
+			f.Edges[p.e.Id()] = amount
+		}
+	}
+	n.levelGraph()
+	for i, id := range n.ids {
+		if n.level[i] >= 0 {
+			f.SourceSide = append(f.SourceSide, id)
+		} else {
+			f.SinkSide = append(f.SinkSide, id)
+		}
+	}
+	for e := range g.AllEdges() {
+		from, okFrom := n.index[e.From()]
+		to, okTo := n.index[e.To()]
+		if !okFrom || !okTo || from == to {
+			continue
+		}
+		crossing := n.level[from] >= 0 && n.level[to] < 0
+		if !e.IsDirected() {
+			crossing = crossing || n.level[to] >= 0 && n.level[from] < 0
+		}
+		if crossing {
+			f.Cut = append(f.Cut, e.Id())
+		}
+	}
+	return f
+}
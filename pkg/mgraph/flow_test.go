@@ -0,0 +1,83 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func newFlowGraph() Graph {
+	g := New(WithDirectedness(Mixed), WithOrdering(InsertionOrder))
+	for _, id := range []VertexID{"s", "a", "b", "c", "t", "x"} {
+		_, _ = g.AddVertex(id)
+	}
+	for _, e := range []struct {
+		id       EdgeID
+		from, to VertexID
+		capacity float64
+	}{
+		{"sa1", "s", "a", 6}, {"sa2", "s", "a", 4}, {"sb", "s", "b", 5},
+		{"ab", "a", "b", 15}, {"ac", "a", "c", 4}, {"ct", "c", "t", 10},
+		{"bt", "b", "t", 8}, {"ta", "t", "a", 3}, {"xt", "x", "t", 9},
+	} {
+		_, _ = g.AddEdge(e.id, e.from, e.to, WithProperty("capacity", e.capacity))
+	}
+	_, _ = g.AddEdge("bc", "c", "b", WithProperty("capacity", 2.0), UndirectedEdge())
+	return g
+}
+
+func byCapacity(e Edge) float64 {
+	c, _ := e.Property("capacity")
+	return c.(float64)
+}
+
+func TestMaxFlow(t *testing.T) {
+	for name, maxFlow := range map[string]func(View, VertexID, VertexID, Capacity[any, any]) (Flow, error){
+		"EdmondsKarp": EdmondsKarp[any, any],
+		"Dinic":       Dinic[any, any],
+	} {
+		g := newFlowGraph()
+		f, err := maxFlow(g, "s", "t", byCapacity)
+		if err != nil || f.Value != 14 {
+			t.Fatalf("%s() expected a flow of 14, got: %v, %v", name, f.Value, err)
+		}
+		if f.Edges["sa1"]+f.Edges["sa2"] > 10 || f.Edges["sa1"] > 6 || f.Edges["ta"] != 0 || f.Edges["bc"] != -2 {
+			t.Fatalf("%s() expected flows within capacities, got: %v", name, f.Edges)
+		}
+		balance := map[VertexID]float64{}
+		for id, flow := range f.Edges {
+			balance[g.Edge(id).From()] -= flow
+			balance[g.Edge(id).To()] += flow
+		}
+		if balance["a"] != 0 || balance["b"] != 0 || balance["c"] != 0 || balance["t"] != 14 {
+			t.Fatalf("%s() expected the flow to be conserved, got: %v", name, balance)
+		}
+		if !slices.Equal(f.SourceSide, []VertexID{"s", "a", "b"}) || !slices.Equal(f.SinkSide, []VertexID{"c", "t", "x"}) {
+			t.Fatalf("%s() expected the cut {s a b} {c t x}, got: %v %v", name, f.SourceSide, f.SinkSide)
+		}
+		var cut float64
+		for _, id := range f.Cut {
+			cut += byCapacity(g.Edge(id))
+		}
+		if !slices.Equal(f.Cut, []EdgeID{"ac", "bt", "bc"}) || cut != f.Value {
+			t.Fatalf("%s() expected the cut edges [ac bt bc], got: %v", name, f.Cut)
+		}
+	}
+}
+
+func TestMaxFlow_Errors(t *testing.T) {
+	g := newFlowGraph()
+	if _, err := Dinic(g, "s", "s", byCapacity); !errors.Is(err, ErrSourceIsSink) {
+		t.Fatalf("Dinic() expected error ErrSourceIsSink, got: %v", err)
+	}
+	if _, err := EdmondsKarp(g, "s", "z", byCapacity); !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("EdmondsKarp() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	_ = g.Edge("ab").SetProperty("capacity", -1.0)
+	if _, err := EdmondsKarp(g, "s", "t", byCapacity); !errors.Is(err, ErrNegativeCapacity) {
+		t.Fatalf("EdmondsKarp() expected error ErrNegativeCapacity, got: %v", err)
+	}
+	if f, _ := Dinic(g, "x", "s", nil); f.Value != 0 || !slices.Equal(f.SinkSide, []VertexID{"s"}) {
+		t.Fatalf("Dinic() expected no flow, got: %v", f.Value)
+	}
+}
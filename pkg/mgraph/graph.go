@@ -3,44 +3,116 @@ package mgraph
 import (
 	"errors"
 	"fmt"
-	"maps"
+	"iter"
+	"time"
 )
 
 var (
 	ErrVertexAlreadyAdded  = errors.New("vertex already added")
 	ErrVertexDoesNotExists = errors.New("vertex does not exists")
 	ErrEdgeAlreadyAdded    = errors.New("edge already added")
+	ErrUndirectedEdge      = errors.New("undirected edges are not allowed on directed graphs")
 )
 
-type Graph interface {
-	AddVertex(id VertexID) (Vertex, error)
-	Vertex(id VertexID) Vertex
+type Graph = TypedGraph[any, any]
+
+type TypedGraph[V, E any] interface {
+	TypedView[V, E]
+	AddVertex(id VertexID, opts ...ElementOption) (TypedVertex[V, E], error)
 	RemoveVertex(id VertexID)
-	Vertices() []Vertex
-	ForEachVertex(func(v Vertex) bool)
-	AddEdge(id EdgeID, from, to VertexID) (Edge, error)
-	Edge(id EdgeID) Edge
+	AddEdge(id EdgeID, from, to VertexID, opts ...ElementOption) (TypedEdge[V, E], error)
 	RemoveEdge(id EdgeID)
-	Edges() []Edge
-	ForEachEdge(func(e Edge) bool)
-	EdgesBetween(from, to VertexID) []Edge
+	CreateVertexIndex(property string, kind IndexKind) error
+	CreateEdgeIndex(property string, kind IndexKind) error
+	DropVertexIndex(property string)
+	DropEdgeIndex(property string)
+	EnsureConsistency(enable bool)
+	EnsureAcyclicity(enable bool) error
+	EnforceSchema(schema *Schema)
+	Begin() TypedTx[V, E]
+	EnableHistory(limit int)
+	Undo() error
+	Redo() error
+	Checkpoint(name string) error
+	RestoreCheckpoint(name string) error
+}
+
+type View = TypedView[any, any]
+
+// TypedView is the read-only part of a TypedGraph, which the algorithms of the
+// package walk. Persistent graphs, versions and temporal views are only views.
+type TypedView[V, E any] interface {
+	Vertex(id VertexID) TypedVertex[V, E]
+	Vertices() []TypedVertex[V, E]
+	ForEachVertex(func(v TypedVertex[V, E]) bool)
+	AllVertices() iter.Seq[TypedVertex[V, E]]
+	VertexEntries() iter.Seq2[VertexID, TypedVertex[V, E]]
+	Edge(id EdgeID) TypedEdge[V, E]
+	Edges() []TypedEdge[V, E]
+	ForEachEdge(func(e TypedEdge[V, E]) bool)
+	AllEdges() iter.Seq[TypedEdge[V, E]]
+	EdgeEntries() iter.Seq2[EdgeID, TypedEdge[V, E]]
+	EdgesBetween(from, to VertexID) []TypedEdge[V, E]
+	VerticesByLabel(label string) []TypedVertex[V, E]
+	EdgesByLabel(label string) []TypedEdge[V, E]
+	EdgesBetweenByLabel(from, to VertexID, label string) []TypedEdge[V, E]
+	OrderByLabel(label string) int
+	SizeByLabel(label string) int
+	VertexLabels() []string
+	EdgeLabels() []string
+	VerticesByProperty(property string, value any) []TypedVertex[V, E]
+	EdgesByProperty(property string, value any) []TypedEdge[V, E]
+	VerticesByPropertyRange(property string, from, to any) ([]TypedVertex[V, E], error)
+	EdgesByPropertyRange(property string, from, to any) ([]TypedEdge[V, E], error)
 	Order() int
 	Size() int
 	Degree() int
-	EnsureConsistency(enable bool)
 	EnsuresConsistency() bool
+	EnsuresAcyclicity() bool
 	IsConsistent() bool
-	Clone() Graph
+	Schema() *Schema
+	Clone() TypedGraph[V, E]
+	CloneWith(cloner DataCloner[V, E]) TypedGraph[V, E]
+	Subscribe(fn func(Event)) (cancel func())
+	Version() uint64
+	At(version uint64) (TypedView[V, E], error)
+	Snapshot() (TypedSnapshot[V, E], error)
+	AsOf(t time.Time) TypedView[V, E]
+	Between(from, to time.Time) TypedView[V, E]
+}
+
+func New(opts ...Option) Graph {
+	return NewTyped[any, any](opts...)
 }
 
-func New() Graph {
-	return &graph{
-		properties: defaultProperties(),
-		vertices:   make(map[VertexID]*vertex),
-		edges:      make(map[EdgeID]*edge),
-		edgesFrom:  make(map[VertexID]map[EdgeID]*edge),
-		edgesTo:    make(map[VertexID]map[EdgeID]*edge),
+func NewTyped[V, E any](opts ...Option) TypedGraph[V, E] {
+	return newGraph[V, E](noLock{}, newProperties(opts))
+}
+
+func newGraph[V, E any](mu locker, properties properties) *graph[V, E] {
+	g := &graph[V, E]{
+		mu:            mu,
+		properties:    properties,
+		vertices:      newCollection[VertexID, *vertex[V, E]](properties.ordering),
+		edges:         newCollection[EdgeID, *edge[V, E]](properties.ordering),
+		edgesFrom:     make(groups[VertexID, EdgeID, *edge[V, E]]),
+		edgesTo:       make(groups[VertexID, EdgeID, *edge[V, E]]),
+		vertexLabels:  make(groups[string, VertexID, *vertex[V, E]]),
+		edgeLabels:    make(groups[string, EdgeID, *edge[V, E]]),
+		labeledFrom:   make(groups[labeledVertex, EdgeID, *edge[V, E]]),
+		labeledTo:     make(groups[labeledVertex, EdgeID, *edge[V, E]]),
+		vertexIndexes: make(indexes[VertexID, *vertex[V, E]]),
+		edgeIndexes:   make(indexes[EdgeID, *edge[V, E]]),
+		txMu:          mu.new(),
+		hub:           &hub{},
+	}
+	if properties.history > 0 {
+		g.history = newHistory(properties.history)
+	}
+	if properties.versioning {
+		g.versions = newVersions(newPersistent[V, E](properties))
 	}
+	return g
 }
 
 func defaultProperties() properties {
@@ -50,192 +122,428 @@ func defaultProperties() properties {
 	}
 }
 
-type graph struct {
+type graph[V, E any] struct {
+	mu         locker
 	properties properties
-	vertices   map[VertexID]*vertex
-	edges      map[EdgeID]*edge
-	edgesFrom  map[VertexID]map[EdgeID]*edge
-	edgesTo    map[VertexID]map[EdgeID]*edge
+	vertices   *collection[VertexID, *vertex[V, E]]
+	edges      *collection[EdgeID, *edge[V, E]]
+	edgesFrom  groups[VertexID, EdgeID, *edge[V, E]]
+	edgesTo    groups[VertexID, EdgeID, *edge[V, E]]
+	order      *topologicalOrder
+
+	vertexLabels groups[string, VertexID, *vertex[V, E]]
+	edgeLabels   groups[string, EdgeID, *edge[V, E]]
+	labeledFrom  groups[labeledVertex, EdgeID, *edge[V, E]]
+	labeledTo    groups[labeledVertex, EdgeID, *edge[V, E]]
+
+	vertexIndexes indexes[VertexID, *vertex[V, E]]
+	edgeIndexes   indexes[EdgeID, *edge[V, E]]
+
+	// seq numbers the vertices and edges in the order they are added.
+	seq      uint64
+	txMu     locker
+	txs      []*tx[V, E]
+	journal  []func()
+	undoing  bool
+	hub      *hub
+	history  *history
+	versions *versions[V, E]
 }
 
 type properties struct {
 	consistency              bool
 	alwaysEnsuredConsistency bool
+	acyclicity               bool
+	ordering                 Ordering
+	directedness             Directedness
+	schema                   *Schema
+	history                  int
+	versioning               bool
 }
 
-func (g *graph) AddVertex(id VertexID) (Vertex, error) {
-	if g.vertices[id] != nil {
+func (g *graph[V, E]) AddVertex(id VertexID, opts ...ElementOption) (TypedVertex[V, E], error) {
+	return g.addVertexBy(nil, id, opts...)
+}
+
+func (g *graph[V, E]) addVertexBy(t *tx[V, E], id VertexID, opts ...ElementOption) (TypedVertex[V, E], error) {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		return nil, fmt.Errorf("error while adding vertex '%s': %w", id, err)
+	}
+	defer unlock()
+	g.seal()
+	o := newElementOptions(opts)
+	if _, ok := g.vertices.get(id); ok {
 		return nil, fmt.Errorf("error while adding vertex '%s': %w", id, ErrVertexAlreadyAdded)
 	}
-	v := newVertex(id, g)
-	g.vertices[id] = v
-	return v, nil
+	if schema := g.properties.schema; schema != nil {
+		if violations := schema.checkVertex(id, o.label, o.properties); len(violations) > 0 {
+			return nil, fmt.Errorf("error while adding vertex '%s': %w", id, &violations[0])
+		}
+	}
+	if err := g.vertexIndexes.check(o.properties); err != nil {
+		return nil, fmt.Errorf("error while adding vertex '%s': %w", id, err)
+	}
+	g.seq++
+	v := newVertex(id, o.label, g.seq, g)
+	v.props = o.properties
+	v.validity = o.validity
+	g.addVertex(v)
+	return t.wrapVertex(v), nil
+}
+
+func (g *graph[V, E]) addVertex(v *vertex[V, E]) {
+	g.vertices.insert(v.id, v, v.seq)
+	g.labelVertex(v)
+	g.vertexIndexes.insert(v)
+	if g.properties.acyclicity {
+		g.order.position(v.id)
+	}
+	g.mirrorVertex(v)
+	g.record(func() { g.removeVertex(v.id) })
+	g.hub.emit(Event{Kind: VertexAdded, Vertex: v.id})
 }
 
-func (g *graph) Vertex(id VertexID) Vertex {
-	if v, ok := g.vertices[id]; ok {
+func (g *graph[V, E]) Vertex(id VertexID) TypedVertex[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.vertex(id)
+}
+
+func (g *graph[V, E]) vertex(id VertexID) TypedVertex[V, E] {
+	if v, ok := g.vertices.get(id); ok {
 		return v
 	}
 	return nil
 }
 
-func (g *graph) RemoveVertex(id VertexID) {
+func (g *graph[V, E]) RemoveVertex(id VertexID) {
+	g.removeVertexBy(nil, id)
+}
+
+func (g *graph[V, E]) removeVertexBy(t *tx[V, E], id VertexID) {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while removing vertex '%s': %w", id, err))
+	}
+	defer unlock()
+	g.seal()
+	g.removeVertex(id)
+}
+
+func (g *graph[V, E]) removeVertex(id VertexID) {
 	if g.properties.consistency {
-		for id := range g.edgesFrom[id] {
-			g.RemoveEdge(id)
+		for id := range g.edgesFrom[id].all() {
+			g.removeEdge(id, true)
 		}
-		for id := range g.edgesTo[id] {
-			g.RemoveEdge(id)
+		for id := range g.edgesTo[id].all() {
+			g.removeEdge(id, true)
 		}
 	}
-	g.vertices[id].onRemove()
-	delete(g.vertices, id)
+	v, _ := g.vertices.get(id)
+	g.vertexLabels.remove(v.label, id)
+	g.vertexIndexes.delete(v)
+	data := v.data
+	v.onRemove()
+	g.vertices.delete(id)
+	if g.properties.acyclicity && g.edgesFrom[id] == nil && g.edgesTo[id] == nil {
+		delete(g.order.positions, id)
+	}
+	g.mirrorVertexRemoval(v)
+	g.record(func() {
+		v.graph, v.data = g, data
+		g.addVertex(v)
+	})
+	g.hub.emit(Event{Kind: VertexRemoved, Vertex: id})
 }
 
-func (g *graph) Vertices() []Vertex {
-	vertices := make([]Vertex, 0, len(g.vertices))
-	for _, v := range g.vertices {
+func (g *graph[V, E]) Vertices() []TypedVertex[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	vertices := make([]TypedVertex[V, E], 0, g.vertices.len())
+	for v := range g.vertices.values() {
 		vertices = append(vertices, v)
 	}
 	return vertices
 }
 
-func (g *graph) ForEachVertex(each func(v Vertex) bool) {
-	for _, v := range g.vertices {
+func (g *graph[V, E]) ForEachVertex(each func(v TypedVertex[V, E]) bool) {
+	for v := range g.AllVertices() {
 		if !each(v) {
 			return
 		}
 	}
 }
 
-func (g *graph) AddEdge(id EdgeID, from, to VertexID) (Edge, error) {
-	if g.edges[id] != nil {
+func (g *graph[V, E]) AddEdge(id EdgeID, from, to VertexID, opts ...ElementOption) (TypedEdge[V, E], error) {
+	return g.addEdgeBy(nil, id, from, to, opts...)
+}
+
+func (g *graph[V, E]) addEdgeBy(t *tx[V, E], id EdgeID, from, to VertexID, opts ...ElementOption) (TypedEdge[V, E], error) {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		return nil, fmt.Errorf("error while adding edge '%s': %w", id, err)
+	}
+	defer unlock()
+	g.seal()
+	o := newElementOptions(opts)
+	if _, ok := g.edges.get(id); ok {
 		return nil, fmt.Errorf("error while adding edge '%s': %w", id, ErrEdgeAlreadyAdded)
 	}
+	directed := g.properties.directedness != Undirected && !o.undirected
+	if !directed && g.properties.directedness == Directed {
+		return nil, fmt.Errorf("error while adding edge '%s': %w", id, ErrUndirectedEdge)
+	}
 	if g.properties.consistency {
-		if g.vertices[from] == nil {
+		if _, ok := g.vertices.get(from); !ok {
 			return nil, fmt.Errorf("error while adding edge '%s': %w", id, fmt.Errorf("vertex '%s' does not exists: %w", from, ErrVertexDoesNotExists))
 		}
-		if g.vertices[to] == nil {
+		if _, ok := g.vertices.get(to); !ok {
 			return nil, fmt.Errorf("error while adding edge '%s': %w", id, fmt.Errorf("vertex '%s' does not exists: %w", to, ErrVertexDoesNotExists))
 		}
 	}
-	e := newEdge(id, from, to, g)
-	g.edges[id] = e
-	if _, ok := g.edgesFrom[from]; !ok {
-		g.edgesFrom[from] = make(map[EdgeID]*edge)
+	if schema := g.properties.schema; schema != nil {
+		if violations := schema.checkEdge(id, o.label, g.vertexLabel(from), g.vertexLabel(to), directed, o.properties); len(violations) > 0 {
+			return nil, fmt.Errorf("error while adding edge '%s': %w", id, &violations[0])
+		}
+	}
+	if err := g.edgeIndexes.check(o.properties); err != nil {
+		return nil, fmt.Errorf("error while adding edge '%s': %w", id, err)
+	}
+	if g.properties.acyclicity && directed {
+		if err := g.orderEdge(from, to); err != nil {
+			return nil, fmt.Errorf("error while adding edge '%s': %w", id, err)
+		}
 	}
-	g.edgesFrom[from][id] = e
-	if _, ok := g.edgesTo[to]; !ok {
-		g.edgesTo[to] = make(map[EdgeID]*edge)
+	g.seq++
+	e := newEdge(id, from, to, o.label, directed, g.seq, g)
+	e.props = o.properties
+	e.validity = o.validity
+	g.addEdge(e)
+	return t.wrapEdge(e), nil
+}
+
+func (g *graph[V, E]) addEdge(e *edge[V, E]) {
+	g.edges.insert(e.id, e, e.seq)
+	g.link(e)
+	g.labelEdge(e)
+	g.edgeIndexes.insert(e)
+	g.mirrorEdge(e)
+	g.record(func() { g.removeEdge(e.id, false) })
+	g.hub.emit(Event{Kind: EdgeAdded, Edge: e.id, From: e.from, To: e.to})
+}
+
+// link indexes an edge in the adjacency of its endpoints. Undirected edges are
+// both outgoing and incoming edges of each of their endpoints.
+func (g *graph[V, E]) link(e *edge[V, E]) {
+	g.edgesFrom.add(e.from, e.id, e, e.seq, g.properties.ordering)
+	g.edgesTo.add(e.to, e.id, e, e.seq, g.properties.ordering)
+	if !e.directed && !e.IsLoop() {
+		g.edgesFrom.add(e.to, e.id, e, e.seq, g.properties.ordering)
+		g.edgesTo.add(e.from, e.id, e, e.seq, g.properties.ordering)
 	}
-	g.edgesTo[to][id] = e
-	return e, nil
 }
 
-func (g *graph) Edge(id EdgeID) Edge {
-	if e, ok := g.edges[id]; ok {
+func (g *graph[V, E]) Edge(id EdgeID) TypedEdge[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if e, ok := g.edges.get(id); ok {
 		return e
 	}
 	return nil
 }
 
-func (g *graph) RemoveEdge(id EdgeID) {
-	edge := g.edges[id]
-	delete(g.edgesFrom[edge.from], id)
-	if len(g.edgesFrom[edge.from]) == 0 {
-		delete(g.edgesFrom, edge.from)
+func (g *graph[V, E]) RemoveEdge(id EdgeID) {
+	g.removeEdgeBy(nil, id)
+}
+
+func (g *graph[V, E]) removeEdgeBy(t *tx[V, E], id EdgeID) {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while removing edge '%s': %w", id, err))
 	}
-	delete(g.edgesTo[edge.to], id)
-	if len(g.edgesTo[edge.to]) == 0 {
-		delete(g.edgesTo, edge.to)
+	defer unlock()
+	g.seal()
+	g.removeEdge(id, false)
+}
+
+func (g *graph[V, E]) removeEdge(id EdgeID, cascade bool) {
+	edge, _ := g.edges.get(id)
+	for _, v := range [2]VertexID{edge.from, edge.to} {
+		g.edgesFrom.remove(v, id)
+		g.edgesTo.remove(v, id)
 	}
+	g.unlabelEdge(edge)
+	g.edgeIndexes.delete(edge)
+	data := edge.data
 	edge.onRemove()
-	delete(g.edges, id)
+	g.edges.delete(id)
+	g.mirrorEdgeRemoval(edge)
+	g.record(func() {
+		if g.properties.acyclicity && edge.directed {
+			_ = g.orderEdge(edge.from, edge.to)
+		}
+		edge.graph, edge.data = g, data
+		g.addEdge(edge)
+	})
+	g.hub.emit(Event{Kind: EdgeRemoved, Edge: id, From: edge.from, To: edge.to, Cascade: cascade})
 }
 
-func (g *graph) Edges() []Edge {
-	edges := make([]Edge, 0, len(g.edges))
-	for _, e := range g.edges {
+func (g *graph[V, E]) Edges() []TypedEdge[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	edges := make([]TypedEdge[V, E], 0, g.edges.len())
+	for e := range g.edges.values() {
 		edges = append(edges, e)
 	}
 	return edges
 }
 
-func (g *graph) ForEachEdge(each func(e Edge) bool) {
-	for _, e := range g.edges {
+func (g *graph[V, E]) ForEachEdge(each func(e TypedEdge[V, E]) bool) {
+	for e := range g.AllEdges() {
 		if !each(e) {
 			return
 		}
 	}
 }
 
-func (g *graph) EdgesBetween(from, to VertexID) (edges []Edge) {
-	for _, e := range g.edgesFrom[from] {
-		if e.to == to {
+func (g *graph[V, E]) EdgesBetween(from, to VertexID) (edges []TypedEdge[V, E]) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for e := range g.edgesFrom[from].values() {
+		if opposite[V, E](e, from) == to {
 			edges = append(edges, e)
 		}
 	}
 	return
 }
 
-func (g *graph) Order() int {
-	return len(g.vertices)
+func (g *graph[V, E]) Order() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.vertices.len()
 }
 
-func (g *graph) Size() int {
-	return len(g.edges)
+func (g *graph[V, E]) Size() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edges.len()
 }
 
-func (g *graph) Degree() (degree int) {
-	for _, v := range g.vertices {
-		if d := v.Degree(); d > degree {
+func (g *graph[V, E]) Degree() (degree int) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for v := range g.vertices.values() {
+		if d := v.degree(); d > degree {
 			degree = d
 		}
 	}
 	return
 }
 
-func (g *graph) EnsureConsistency(enable bool) {
+func (g *graph[V, E]) EnsureConsistency(enable bool) {
+	g.ensureConsistencyBy(nil, enable)
+}
+
+func (g *graph[V, E]) ensureConsistencyBy(t *tx[V, E], enable bool) {
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while ensuring consistency: %w", err))
+	}
+	defer unlock()
+	g.seal()
+	old := g.properties
+	g.record(func() {
+		g.properties.consistency = old.consistency
+		g.properties.alwaysEnsuredConsistency = old.alwaysEnsuredConsistency
+	})
 	if !enable {
 		g.properties.alwaysEnsuredConsistency = false
 	}
 	g.properties.consistency = enable
 }
 
-func (g *graph) EnsuresConsistency() bool {
+func (g *graph[V, E]) EnsuresConsistency() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	return g.properties.consistency
 }
 
-func (g *graph) IsConsistent() bool {
+func (g *graph[V, E]) IsConsistent() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
 	if g.properties.alwaysEnsuredConsistency {
 		return true
 	}
-	for _, e := range g.edges {
-		if g.vertices[e.from] == nil || g.vertices[e.to] == nil {
+	for e := range g.edges.values() {
+		_, fromOk := g.vertices.get(e.from)
+		_, toOk := g.vertices.get(e.to)
+		if !fromOk || !toOk {
 			return false
 		}
 	}
 	return true
 }
 
-func (g *graph) Clone() Graph {
-	newG := &graph{
-		properties: g.properties,
+// DataCloner copies vertex and edge payloads while cloning a graph. A nil function
+// copies the payload as is.
+type DataCloner[V, E any] struct {
+	Vertex func(data V) V
+	Edge   func(data E) E
+}
+
+func (g *graph[V, E]) Clone() TypedGraph[V, E] {
+	return g.CloneWith(DataCloner[V, E]{})
+}
+
+func (g *graph[V, E]) CloneWith(cloner DataCloner[V, E]) TypedGraph[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	newG := newGraph[V, E](g.mu.new(), g.properties)
+	newG.order = g.order.clone()
+	newG.seq = g.seq
+	for id, v := range g.vertices.all() {
+		newV := newVertex(id, v.label, v.seq, newG)
+		newV.props = copyProperties(v.props)
+		newV.validity = v.validity
+		newV.data = v.data
+		if cloner.Vertex != nil {
+			newV.data = cloner.Vertex(v.data)
+		}
+		newG.vertices.insert(id, newV, newV.seq)
+		newG.labelVertex(newV)
+	}
+	for id, e := range g.edges.all() {
+		newE := newEdge(id, e.from, e.to, e.label, e.directed, e.seq, newG)
+		newE.props = copyProperties(e.props)
+		newE.validity = e.validity
+		newE.data = e.data
+		if cloner.Edge != nil {
+			newE.data = cloner.Edge(e.data)
+		}
+		newG.edges.insert(id, newE, newE.seq)
+		newG.labelEdge(newE)
 	}
-	newG.vertices = make(map[VertexID]*vertex, len(g.vertices))
-	newG.edges = make(map[EdgeID]*edge, len(g.edges))
-	newG.edgesFrom = make(map[VertexID]map[EdgeID]*edge, len(g.edgesFrom))
-	newG.edgesTo = make(map[VertexID]map[EdgeID]*edge, len(g.edgesTo))
-	maps.Copy(newG.vertices, g.vertices)
-	maps.Copy(newG.edges, g.edges)
+	newG.vertexIndexes = g.vertexIndexes.clone(g.properties.ordering, newG.vertices.values())
+	newG.edgeIndexes = g.edgeIndexes.clone(g.properties.ordering, newG.edges.values())
 	for id, from := range g.edgesFrom {
-		newG.edgesFrom[id] = make(map[EdgeID]*edge, len(from))
-		maps.Copy(newG.edgesFrom[id], from)
+		for eid := range from.all() {
+			newE, _ := newG.edges.get(eid)
+			newG.edgesFrom.add(id, eid, newE, newE.seq, g.properties.ordering)
+		}
 	}
 	for id, to := range g.edgesTo {
-		newG.edgesTo[id] = make(map[EdgeID]*edge, len(to))
-		maps.Copy(newG.edgesTo[id], to)
+		for eid := range to.all() {
+			newE, _ := newG.edges.get(eid)
+			newG.edgesTo.add(id, eid, newE, newE.seq, g.properties.ordering)
+		}
+	}
+	if newG.versions != nil {
+		newG.versions = newVersions(newG.freeze())
 	}
 	return newG
 }
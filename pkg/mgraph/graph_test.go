@@ -2,6 +2,7 @@ package mgraph
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -13,6 +14,13 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewTyped(t *testing.T) {
+	g := NewTyped[string, int]()
+	if g == nil {
+		t.Fatalf("NewTyped() returned nil")
+	}
+}
+
 func TestGraph_AddVertex(t *testing.T) {
 	g := New()
 	v, err := g.AddVertex("node1")
@@ -434,4 +442,123 @@ func TestGraph_Clone(t *testing.T) {
 	if g.Edge("edge4") != nil {
 		t.Fatalf("Clone() expected to be a deep copy, got: %v", g.Edge("edge4"))
 	}
+	if clone.Vertex("node1").Graph() != clone {
+		t.Fatalf("Clone() expected vertices bound to the clone, got: %v", clone.Vertex("node1").Graph())
+	}
+	if clone.Edge("edge1").Graph() != clone || clone.Edge("edge1").Tail() != clone.Vertex("node1") {
+		t.Fatalf("Clone() expected edges bound to the clone, got: %v", clone.Edge("edge1").Graph())
+	}
+}
+
+func TestGraph_Clone_RemoveOnCloneKeepsOriginal(t *testing.T) {
+	g := New()
+	v, _ := g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	e, _ := g.AddEdge("edge1", "node1", "node2")
+	v.StoreData("data")
+	e.StoreData("data")
+	clone := g.Clone()
+	clone.RemoveVertex("node1")
+	if v.Graph() != g || v.Data() != "data" {
+		t.Fatalf("RemoveVertex() on clone expected to keep original vertex, got: %v %v", v.Graph(), v.Data())
+	}
+	if e.Graph() != g || e.Data() != "data" || g.Edge("edge1") == nil {
+		t.Fatalf("RemoveVertex() on clone expected to keep original edge, got: %v %v", e.Graph(), e.Data())
+	}
+	if len(g.Vertex("node2").Incoming()) != 1 {
+		t.Fatalf("RemoveVertex() on clone expected to keep original adjacency, got: %v", g.Vertex("node2").Incoming())
+	}
+}
+
+func TestGraph_CloneWith(t *testing.T) {
+	g := NewTyped[[]string, []string]()
+	v, _ := g.AddVertex("node1")
+	v.StoreData([]string{"image1"})
+	_, _ = g.AddVertex("node2")
+	e, _ := g.AddEdge("edge1", "node1", "node2")
+	e.StoreData([]string{"tag1"})
+	clone := g.CloneWith(DataCloner[[]string, []string]{
+		Vertex: slices.Clone[[]string],
+	})
+	clone.Vertex("node1").Data()[0] = "image2"
+	if v.Data()[0] != "image1" {
+		t.Fatalf("CloneWith() expected vertex data to be deep copied, got: %v", v.Data())
+	}
+	clone.Edge("edge1").Data()[0] = "tag2"
+	if e.Data()[0] != "tag2" {
+		t.Fatalf("CloneWith() expected edge data to be copied as is, got: %v", e.Data())
+	}
+}
+
+func TestTypedGraph_SharesConsistencyRules(t *testing.T) {
+	g := NewTyped[string, int]()
+	_, err := g.AddEdge("edge1", "node1", "node2")
+	if !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("AddEdge() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	g.RemoveVertex("node1")
+	if g.Edge("edge1") != nil {
+		t.Fatalf("RemoveVertex() expected to remove edge, got: %v", g.Edge("edge1"))
+	}
+}
+
+func TestGraph_WithDirectedness_Undirected(t *testing.T) {
+	g := New(WithDirectedness(Undirected))
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddVertex("node3")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	_, _ = g.AddEdge("edge2", "node3", "node1")
+	_, _ = g.AddEdge("edge3", "node1", "node1")
+	if len(g.EdgesBetween("node1", "node2")) != 1 || len(g.EdgesBetween("node2", "node1")) != 1 {
+		t.Fatalf("EdgesBetween() expected the same edge in both directions, got: %v %v", g.EdgesBetween("node1", "node2"), g.EdgesBetween("node2", "node1"))
+	}
+	v := g.Vertex("node1")
+	if len(v.Incoming()) != 3 || len(v.Outgoing()) != 3 || len(v.Edges()) != 3 {
+		t.Fatalf("Incoming(), Outgoing() and Edges() expected 3 edges, got: %d %d %d", len(v.Incoming()), len(v.Outgoing()), len(v.Edges()))
+	}
+	if v.Degree() != 4 {
+		t.Fatalf("Degree() expected 4, got: %d", v.Degree())
+	}
+	if g.Degree() != 4 {
+		t.Fatalf("Degree() expected 4, got: %d", g.Degree())
+	}
+	g.RemoveEdge("edge1")
+	if len(g.Vertex("node2").Outgoing()) != 0 || g.Vertex("node2").Degree() != 0 {
+		t.Fatalf("RemoveEdge() expected to unlink both endpoints, got: %v", g.Vertex("node2").Outgoing())
+	}
+	g.RemoveVertex("node1")
+	if g.Size() != 0 {
+		t.Fatalf("RemoveVertex() expected to remove incident edges, got: %v", g.Edges())
+	}
+}
+
+func TestGraph_WithDirectedness_Mixed(t *testing.T) {
+	g := New(WithDirectedness(Mixed))
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	e1, _ := g.AddEdge("edge1", "node1", "node2")
+	e2, _ := g.AddEdge("edge2", "node1", "node2", UndirectedEdge())
+	if !e1.IsDirected() || e2.IsDirected() {
+		t.Fatalf("AddEdge() expected edge1 directed and edge2 undirected, got: %v %v", e1.IsDirected(), e2.IsDirected())
+	}
+	if len(g.EdgesBetween("node1", "node2")) != 2 || len(g.EdgesBetween("node2", "node1")) != 1 {
+		t.Fatalf("EdgesBetween() expected 2 and 1 edges, got: %v %v", g.EdgesBetween("node1", "node2"), g.EdgesBetween("node2", "node1"))
+	}
+	v := g.Vertex("node2")
+	if len(v.Incoming()) != 2 || len(v.Outgoing()) != 1 || v.Degree() != 2 {
+		t.Fatalf("node2 expected 2 incoming, 1 outgoing and degree 2, got: %d %d %d", len(v.Incoming()), len(v.Outgoing()), v.Degree())
+	}
+}
+
+func TestGraph_AddEdgeOnDirectedGraph_ErrorUndirectedEdge(t *testing.T) {
+	g := New()
+	_, _ = g.AddVertex("node1")
+	_, err := g.AddEdge("edge1", "node1", "node1", UndirectedEdge())
+	if !errors.Is(err, ErrUndirectedEdge) {
+		t.Fatalf("AddEdge() expected error ErrUndirectedEdge, got: %v", err)
+	}
 }
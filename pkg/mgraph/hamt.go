@@ -0,0 +1,236 @@
+package mgraph
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// hamt is a persistent hash array mapped trie. Updates copy the nodes on the path
+// to the changed key and share every other node with the original trie, which is
+// left untouched, so both cost O(log n). The zero value is empty.
+type hamt[K ~string, T any] struct {
+	root *hamtNode[K, T]
+	size int
+}
+
+// hamtNode dispatches on 5 bits of the key hashes, keeping only the entries in
+// use along with a bitmap of their slots. Keys whose 64-bit hashes collide end
+// up in a node past the last level, which lists them.
+type hamtNode[K ~string, T any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, T]
+}
+
+// hamtEntry is either a child node or a key with its value.
+type hamtEntry[K ~string, T any] struct {
+	node  *hamtNode[K, T]
+	hash  uint64
+	key   K
+	value T
+}
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+var hamtSeed = maphash.MakeSeed()
+
+func (m hamt[K, T]) len() int {
+	return m.size
+}
+
+func (m hamt[K, T]) get(key K) (T, bool) {
+	h := maphash.String(hamtSeed, string(key))
+	n := m.root
+	for shift := 0; n != nil; shift += hamtBits {
+		if shift >= 64 {
+			if i := n.find(key); i >= 0 {
+				return n.entries[i].value, true
+			}
+			break
+		}
+		bit := uint32(1) << (h >> shift & hamtMask)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		e := n.entries[n.index(bit)]
+		if e.node == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			break
+		}
+		n = e.node
+	}
+	var zero T
+	return zero, false
+}
+
+func (m hamt[K, T]) set(key K, value T) hamt[K, T] {
+	root, added := m.root.set(0, maphash.String(hamtSeed, string(key)), key, value)
+	m.root = root
+	if added {
+		m.size++
+	}
+	return m
+}
+
+func (m hamt[K, T]) delete(key K) hamt[K, T] {
+	root, removed := m.root.delete(0, maphash.String(hamtSeed, string(key)), key)
+	if removed {
+		m.root = root
+		m.size--
+	}
+	return m
+}
+
+// all enumerates the trie in the order of the key hashes, which is stable for a
+// given set of keys within a process.
+func (m hamt[K, T]) all() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		m.root.each(yield)
+	}
+}
+
+func (m hamt[K, T]) keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.all() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (n *hamtNode[K, T]) index(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, T]) find(key K) int {
+	return slices.IndexFunc(n.entries, func(e hamtEntry[K, T]) bool { return e.node == nil && e.key == key })
+}
+
+func (n *hamtNode[K, T]) set(shift int, h uint64, key K, value T) (*hamtNode[K, T], bool) {
+	leaf := hamtEntry[K, T]{hash: h, key: key, value: value}
+	if n == nil {
+		n = &hamtNode[K, T]{}
+	}
+	if shift >= 64 {
+		i := n.find(key)
+		if i < 0 {
+			return &hamtNode[K, T]{entries: append(slices.Clone(n.entries), leaf)}, true
+		}
+		entries := slices.Clone(n.entries)
+		entries[i] = leaf
+		return &hamtNode[K, T]{entries: entries}, false
+	}
+	bit := uint32(1) << (h >> shift & hamtMask)
+	i := n.index(bit)
+	if n.bitmap&bit == 0 {
+		entries := make([]hamtEntry[K, T], 0, len(n.entries)+1)
+		entries = append(append(append(entries, n.entries[:i]...), leaf), n.entries[i:]...)
+		return &hamtNode[K, T]{bitmap: n.bitmap | bit, entries: entries}, true
+	}
+	e, added := n.entries[i], false
+	switch {
+	case e.node != nil:
+		e.node, added = e.node.set(shift+hamtBits, h, key, value)
+	case e.key == key:
+		e = leaf
+	default:
+		child, _ := (*hamtNode[K, T])(nil).set(shift+hamtBits, e.hash, e.key, e.value)
+		child, _ = child.set(shift+hamtBits, h, key, value)
+		e, added = hamtEntry[K, T]{node: child}, true
+	}
+	entries := slices.Clone(n.entries)
+	entries[i] = e
+	return &hamtNode[K, T]{bitmap: n.bitmap, entries: entries}, added
+}
+
+// delete returns the node without the key, or nil once it is empty. A child left
+// with a single key is replaced by that key, so that lookups stay short.
+func (n *hamtNode[K, T]) delete(shift int, h uint64, key K) (*hamtNode[K, T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if shift >= 64 {
+		i := n.find(key)
+		switch {
+		case i < 0:
+			return n, false
+		case len(n.entries) == 1:
+			return nil, true
+		}
+		return &hamtNode[K, T]{entries: slices.Delete(slices.Clone(n.entries), i, i+1)}, true
+	}
+	bit := uint32(1) << (h >> shift & hamtMask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	i := n.index(bit)
+	e := n.entries[i]
+	if e.node != nil {
+		child, removed := e.node.delete(shift+hamtBits, h, key)
+		if !removed {
+			return n, false
+		}
+		if child != nil {
+			if len(child.entries) == 1 && child.entries[0].node == nil {
+				e = child.entries[0]
+			} else {
+				e.node = child
+			}
+			entries := slices.Clone(n.entries)
+			entries[i] = e
+			return &hamtNode[K, T]{bitmap: n.bitmap, entries: entries}, true
+		}
+	} else if e.key != key {
+		return n, false
+	}
+	if n.bitmap == bit {
+		return nil, true
+	}
+	return &hamtNode[K, T]{bitmap: n.bitmap &^ bit, entries: slices.Delete(slices.Clone(n.entries), i, i+1)}, true
+}
+
+func (n *hamtNode[K, T]) each(yield func(K, T) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, e := range n.entries {
+		if e.node != nil {
+			if !e.node.each(yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// addMember adds a key to the set of a group, such as an edge to the adjacency
+// of a vertex.
+func addMember[G, K ~string](groups hamt[G, hamt[K, struct{}]], group G, key K) hamt[G, hamt[K, struct{}]] {
+	members, _ := groups.get(group)
+	return groups.set(group, members.set(key, struct{}{}))
+}
+
+// removeMember removes a key from the set of a group, dropping the set once it is
+// empty.
+func removeMember[G, K ~string](groups hamt[G, hamt[K, struct{}]], group G, key K) hamt[G, hamt[K, struct{}]] {
+	members, ok := groups.get(group)
+	if !ok {
+		return groups
+	}
+	if _, ok := members.get(key); !ok {
+		return groups
+	}
+	if members = members.delete(key); members.len() == 0 {
+		return groups.delete(group)
+	}
+	return groups.set(group, members)
+}
@@ -0,0 +1,69 @@
+package mgraph
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"testing"
+)
+
+func TestHamt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var m hamt[string, int]
+	expected := map[string]int{}
+	var versions []hamt[string, int]
+	var snapshots []map[string]int
+	for i := 0; i < 5000; i++ {
+		key := fmt.Sprint(r.Intn(1000))
+		if r.Intn(3) == 0 {
+			m = m.delete(key)
+			delete(expected, key)
+		} else {
+			m = m.set(key, i)
+			expected[key] = i
+		}
+		if i%500 == 0 {
+			versions = append(versions, m)
+			snapshots = append(snapshots, maps.Clone(expected))
+		}
+	}
+	versions = append(versions, m)
+	snapshots = append(snapshots, expected)
+	for i, version := range versions {
+		if version.len() != len(snapshots[i]) {
+			t.Fatalf("len() expected %d for version %d, got: %d", len(snapshots[i]), i, version.len())
+		}
+		if all := maps.Collect(version.all()); !maps.Equal(all, snapshots[i]) {
+			t.Fatalf("all() expected version %d to be unchanged by later updates", i)
+		}
+		for key, value := range snapshots[i] {
+			if got, ok := version.get(key); !ok || got != value {
+				t.Fatalf("get(%s) expected %d for version %d, got: %d, %v", key, value, i, got, ok)
+			}
+		}
+	}
+}
+
+func TestHamt_Collisions(t *testing.T) {
+	var n *hamtNode[string, int]
+	for i, key := range []string{"a", "b", "c"} {
+		n, _ = n.set(0, 42, key, i)
+	}
+	m := hamt[string, int]{root: n, size: 3}
+	if all := maps.Collect(m.all()); !maps.Equal(all, map[string]int{"a": 0, "b": 1, "c": 2}) {
+		t.Fatalf("all() expected the colliding keys, got: %v", all)
+	}
+	for _, key := range []string{"b", "a"} {
+		var removed bool
+		n, removed = n.delete(0, 42, key)
+		if !removed {
+			t.Fatalf("delete(%s) expected the key to be removed", key)
+		}
+	}
+	if len(n.entries) != 1 || n.entries[0].node != nil || n.entries[0].key != "c" {
+		t.Fatalf("delete() expected the last key to be pulled up to the root")
+	}
+	if n, _ = n.delete(0, 42, "c"); n != nil {
+		t.Fatalf("delete() expected an empty trie")
+	}
+}
@@ -0,0 +1,249 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+var (
+	ErrHistoryDisabled    = errors.New("history is not enabled")
+	ErrNothingToUndo      = errors.New("nothing to undo")
+	ErrNothingToRedo      = errors.New("nothing to redo")
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	ErrTxOpen             = errors.New("a transaction is open")
+)
+
+// history journals the inverses of the mutations of a graph so that they can be
+// undone and redone. Each public mutation, such as RemoveVertex with its cascaded
+// edge removals, is undone as a whole, and so is each committed transaction; a
+// rolled back one leaves no trace. Only the last limit mutations are kept. The
+// history cannot be moved through while a transaction is open.
+//
+// Every mutation leads the graph to a new state, numbered from 1; checkpoints
+// name states, which can be restored while undoing or redoing can reach them, and
+// are forgotten once they cannot. The payloads captured by the undo steps, such
+// as the data replaced by StoreData, are kept along with them: their memory is
+// only bounded by the limit on the number of mutations, not by their size.
+type history struct {
+	limit       int
+	current     []func()
+	done        []change
+	undone      []change
+	state       uint64
+	states      uint64
+	checkpoints map[string]uint64
+}
+
+// change leads from a state to another one; its steps are run in reverse order.
+type change struct {
+	steps  []func()
+	before uint64
+	after  uint64
+}
+
+func newHistory(limit int) *history {
+	return &history{limit: limit, checkpoints: make(map[string]uint64)}
+}
+
+// seal closes the mutation being recorded. A new mutation discards the changes
+// that were undone.
+func (h *history) seal() {
+	if len(h.current) == 0 {
+		return
+	}
+	for _, c := range h.undone {
+		h.forget(c.after)
+	}
+	clear(h.undone)
+	h.undone = nil
+	h.states++
+	h.push(&h.done, change{steps: h.current, before: h.state, after: h.states})
+	h.state = h.states
+	h.current = nil
+}
+
+// push adds a change on top of a stack, dropping the oldest one at the limit
+// along with the checkpoints of the state it led to from the current one.
+func (h *history) push(stack *[]change, c change) {
+	if len(*stack) == h.limit {
+		if stack == &h.done {
+			h.forget((*stack)[0].before)
+		} else {
+			h.forget((*stack)[0].after)
+		}
+		clear((*stack)[:1])
+		*stack = slices.Delete(*stack, 0, 1)
+	}
+	*stack = append(*stack, c)
+}
+
+// forget drops the checkpoints of a state that can no longer be reached.
+func (h *history) forget(state uint64) {
+	maps.DeleteFunc(h.checkpoints, func(_ string, s uint64) bool { return s == state })
+}
+
+// replay runs the steps of a change, recording their own inverses, and moves it
+// from one stack to the other.
+func (h *history) replay(from, to *[]change, state func(c change) uint64) {
+	c := (*from)[len(*from)-1]
+	(*from)[len(*from)-1] = change{}
+	*from = (*from)[:len(*from)-1]
+	for i := len(c.steps) - 1; i >= 0; i-- {
+		c.steps[i]()
+	}
+	c.steps, h.current = h.current, nil
+	h.push(to, c)
+	h.state = state(c)
+}
+
+func (h *history) undo() {
+	h.replay(&h.done, &h.undone, func(c change) uint64 { return c.before })
+}
+
+func (h *history) redo() {
+	h.replay(&h.undone, &h.done, func(c change) uint64 { return c.after })
+}
+
+// seal closes the mutation being recorded by the history, if any, and commits
+// the previous mutation as a new version. While a transaction is open, its
+// mutations are recorded as a single one, closed once it commits.
+func (g *graph[V, E]) seal() {
+	if g == nil {
+		return
+	}
+	if g.history != nil && len(g.txs) == 0 {
+		g.history.seal()
+	}
+	g.commit()
+}
+
+// EnableHistory starts journaling the mutations of the graph so that the last
+// limit ones can be undone. A limit of 0 or less disables and drops the history.
+func (g *graph[V, E]) EnableHistory(limit int) {
+	g.enableHistoryBy(nil, limit)
+}
+
+func (g *graph[V, E]) enableHistoryBy(t *tx[V, E], limit int) {
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while enabling history: %w", err))
+	}
+	defer unlock()
+	g.properties.history = max(limit, 0)
+	g.history = nil
+	if limit > 0 {
+		g.history = newHistory(limit)
+	}
+}
+
+func (g *graph[V, E]) Undo() error {
+	return g.undoBy(nil)
+}
+
+func (g *graph[V, E]) undoBy(t *tx[V, E]) error {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while undoing: %w", err)
+	}
+	defer unlock()
+	if g.history == nil {
+		return fmt.Errorf("error while undoing: %w", ErrHistoryDisabled)
+	}
+	if len(g.txs) > 0 {
+		return fmt.Errorf("error while undoing: %w", ErrTxOpen)
+	}
+	g.seal()
+	if len(g.history.done) == 0 {
+		return fmt.Errorf("error while undoing: %w", ErrNothingToUndo)
+	}
+	g.history.undo()
+	return nil
+}
+
+func (g *graph[V, E]) Redo() error {
+	return g.redoBy(nil)
+}
+
+func (g *graph[V, E]) redoBy(t *tx[V, E]) error {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while redoing: %w", err)
+	}
+	defer unlock()
+	if g.history == nil {
+		return fmt.Errorf("error while redoing: %w", ErrHistoryDisabled)
+	}
+	if len(g.txs) > 0 {
+		return fmt.Errorf("error while redoing: %w", ErrTxOpen)
+	}
+	g.seal()
+	if len(g.history.undone) == 0 {
+		return fmt.Errorf("error while redoing: %w", ErrNothingToRedo)
+	}
+	g.history.redo()
+	return nil
+}
+
+// Checkpoint names the current state of the graph.
+func (g *graph[V, E]) Checkpoint(name string) error {
+	return g.checkpointBy(nil, name)
+}
+
+func (g *graph[V, E]) checkpointBy(t *tx[V, E], name string) error {
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while creating checkpoint '%s': %w", name, err)
+	}
+	defer unlock()
+	if g.history == nil {
+		return fmt.Errorf("error while creating checkpoint '%s': %w", name, ErrHistoryDisabled)
+	}
+	if len(g.txs) > 0 {
+		return fmt.Errorf("error while creating checkpoint '%s': %w", name, ErrTxOpen)
+	}
+	g.seal()
+	g.history.checkpoints[name] = g.history.state
+	return nil
+}
+
+// RestoreCheckpoint undoes or redoes mutations until the graph is back to the
+// named state. It fails, leaving the graph unchanged, when the checkpoint was
+// forgotten as its state went beyond the history limit or was undone and then
+// discarded by a new mutation.
+func (g *graph[V, E]) RestoreCheckpoint(name string) error {
+	return g.restoreCheckpointBy(nil, name)
+}
+
+func (g *graph[V, E]) restoreCheckpointBy(t *tx[V, E], name string) error {
+	defer g.hub.publish()
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while restoring checkpoint '%s': %w", name, err)
+	}
+	defer unlock()
+	h := g.history
+	if h == nil {
+		return fmt.Errorf("error while restoring checkpoint '%s': %w", name, ErrHistoryDisabled)
+	}
+	if len(g.txs) > 0 {
+		return fmt.Errorf("error while restoring checkpoint '%s': %w", name, ErrTxOpen)
+	}
+	g.seal()
+	state, ok := h.checkpoints[name]
+	if !ok {
+		return fmt.Errorf("error while restoring checkpoint '%s': %w", name, ErrCheckpointNotFound)
+	}
+	undo := slices.ContainsFunc(h.done, func(c change) bool { return c.before == state })
+	for h.state != state {
+		if undo {
+			h.undo()
+		} else {
+			h.redo()
+		}
+	}
+	return nil
+}
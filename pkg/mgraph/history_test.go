@@ -0,0 +1,166 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGraph_UndoRedo(t *testing.T) {
+	g := newTxGraph()
+	g.EnableHistory(10)
+	before := dumpGraph(g)
+	g.Vertex("a").StoreData("first")
+	g.Vertex("a").StoreData("second")
+	g.RemoveVertex("c")
+	afterRemoval := dumpGraph(g)
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() expected no error, got: %v", err)
+	}
+	if g.Vertex("c") == nil || g.Size() != 5 {
+		t.Fatalf("Undo() expected vertex c and its 3 edges to be restored, got: %d edges", g.Size())
+	}
+	_ = g.Undo()
+	if data := g.Vertex("a").Data(); data != "first" {
+		t.Fatalf("Undo() expected data first, got: %v", data)
+	}
+	_ = g.Undo()
+	if after := dumpGraph(g); after != before {
+		t.Fatalf("Undo() expected graph to be restored to\n%s, got:\n%s", before, after)
+	}
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo() expected error ErrNothingToUndo, got: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := g.Redo(); err != nil {
+			t.Fatalf("Redo() expected no error, got: %v", err)
+		}
+	}
+	if after := dumpGraph(g); after != afterRemoval {
+		t.Fatalf("Redo() expected graph to be\n%s, got:\n%s", afterRemoval, after)
+	}
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("Redo() expected error ErrNothingToRedo, got: %v", err)
+	}
+}
+
+func TestGraph_Undo_NewMutationDiscardsRedo(t *testing.T) {
+	g := New(WithHistory(10))
+	_, _ = g.AddVertex("a")
+	_ = g.Undo()
+	_, _ = g.AddVertex("b")
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("Redo() expected error ErrNothingToRedo, got: %v", err)
+	}
+	if g.Vertex("a") != nil || g.Vertex("b") == nil {
+		t.Fatalf("Undo() expected only vertex b to remain")
+	}
+}
+
+func TestGraph_Checkpoints(t *testing.T) {
+	g := New(WithHistory(10))
+	_, _ = g.AddVertex("a")
+	_ = g.Checkpoint("one")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddEdge("ab", "a", "b")
+	_ = g.Checkpoint("two")
+	_ = g.Edge("ab").SetProperty("weight", 1)
+	if err := g.RestoreCheckpoint("one"); err != nil {
+		t.Fatalf("RestoreCheckpoint() expected no error, got: %v", err)
+	}
+	if g.Order() != 1 || g.Size() != 0 {
+		t.Fatalf("RestoreCheckpoint() expected 1 vertex and no edge, got: %d vertices and %d edges", g.Order(), g.Size())
+	}
+	if err := g.RestoreCheckpoint("two"); err != nil {
+		t.Fatalf("RestoreCheckpoint() expected no error, got: %v", err)
+	}
+	if _, ok := g.Edge("ab").Property("weight"); g.Size() != 1 || ok {
+		t.Fatalf("RestoreCheckpoint() expected edge ab without weight")
+	}
+	if err := g.RestoreCheckpoint("three"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("RestoreCheckpoint() expected error ErrCheckpointNotFound, got: %v", err)
+	}
+	_ = g.RestoreCheckpoint("one")
+	_, _ = g.AddVertex("c")
+	if err := g.RestoreCheckpoint("two"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("RestoreCheckpoint() expected error ErrCheckpointNotFound, got: %v", err)
+	}
+}
+
+func TestGraph_History_Limit(t *testing.T) {
+	g := New(WithHistory(2))
+	_ = g.Checkpoint("empty")
+	for _, id := range []VertexID{"a", "b", "c"} {
+		_, _ = g.AddVertex(id)
+	}
+	_ = g.Undo()
+	_ = g.Undo()
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo() expected error ErrNothingToUndo beyond the limit, got: %v", err)
+	}
+	if g.Order() != 1 {
+		t.Fatalf("Undo() expected vertex a to remain, got: %d vertices", g.Order())
+	}
+	if err := g.RestoreCheckpoint("empty"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Fatalf("RestoreCheckpoint() expected error ErrCheckpointNotFound, got: %v", err)
+	}
+}
+
+func TestGraph_History_ForgetsCheckpoints(t *testing.T) {
+	g := New(WithHistory(2))
+	for i := 0; i < 10; i++ {
+		_, _ = g.AddVertex(VertexID(fmt.Sprint(i)))
+		_ = g.Checkpoint(fmt.Sprint(i))
+	}
+	if checkpoints := g.(*graph[any, any]).history.checkpoints; len(checkpoints) != 3 {
+		t.Fatalf("Checkpoint() expected the 3 reachable checkpoints to be kept, got: %v", checkpoints)
+	}
+	if err := g.RestoreCheckpoint("7"); err != nil || g.Order() != 8 {
+		t.Fatalf("RestoreCheckpoint() expected 8 vertices, got: %d vertices and error %v", g.Order(), err)
+	}
+}
+
+func TestGraph_Undo_ErrorHistoryDisabled(t *testing.T) {
+	g := New()
+	if err := g.Undo(); !errors.Is(err, ErrHistoryDisabled) {
+		t.Fatalf("Undo() expected error ErrHistoryDisabled, got: %v", err)
+	}
+}
+
+func TestGraph_Undo_AfterRollback(t *testing.T) {
+	g := New(WithHistory(10))
+	_, _ = g.AddVertex("a")
+	tx := g.Begin()
+	tx.RemoveVertex("a")
+	_, _ = tx.AddVertex("b")
+	_ = tx.Rollback()
+	if err := g.Undo(); err != nil || g.Order() != 0 {
+		t.Fatalf("Undo() expected to undo the addition of a, got: %d vertices, %v", g.Order(), err)
+	}
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("Undo() expected error ErrNothingToUndo, got: %v", err)
+	}
+}
+
+func TestGraph_Undo_AfterCommit(t *testing.T) {
+	g := New(WithHistory(10))
+	_, _ = g.AddVertex("a")
+	tx := g.Begin()
+	_, _ = tx.AddVertex("b")
+	inner := tx.Begin()
+	_, _ = inner.AddVertex("c")
+	_ = inner.Rollback()
+	_, _ = tx.AddEdge("ab", "a", "b")
+	if err := tx.Undo(); !errors.Is(err, ErrTxOpen) {
+		t.Fatalf("Undo() expected error ErrTxOpen, got: %v", err)
+	}
+	_ = tx.Commit()
+	_ = g.Undo()
+	if g.Order() != 1 || g.Size() != 0 {
+		t.Fatalf("Undo() expected to undo the transaction as a whole, got: %d vertices", g.Order())
+	}
+	_ = g.Redo()
+	if g.Vertex("b") == nil || g.Vertex("c") != nil || g.Edge("ab") == nil {
+		t.Fatalf("Redo() expected to redo the transaction without its rolled back part")
+	}
+}
@@ -0,0 +1,364 @@
+package mgraph
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrIndexAlreadyCreated = errors.New("index already created")
+	ErrUncomparableValue   = errors.New("value cannot be compared")
+	ErrUnorderedValue      = errors.New("value cannot be ordered")
+)
+
+type IndexKind int
+
+const (
+	// HashIndex answers equality lookups; its values must be comparable.
+	HashIndex IndexKind = iota
+	// OrderedIndex also answers range lookups; its values must be booleans,
+	// numbers or strings, which are ordered in that sequence.
+	OrderedIndex
+)
+
+// element is what indexes hold: a vertex or an edge with its property map.
+type element[K cmp.Ordered] interface {
+	comparable
+	Id() K
+	serial() uint64
+	propertyMap() map[string]any
+}
+
+// index maps the values of one property to the elements holding them. Hash
+// indexes group elements by value in graph order; ordered ones sort them by value,
+// then by ID, so ranges are found by binary search. Like collections sorted by ID,
+// ordered indexes buffer their writes and sort them on the next lookup, so that
+// indexing n elements takes O(n log n) rather than O(n²).
+type index[K cmp.Ordered, T element[K]] struct {
+	kind     IndexKind
+	ordering Ordering
+	hashed   groups[any, K, T]
+	// values holds the value of every element of an ordered index. Entries are
+	// live while they match it: removing an element leaves its entry behind.
+	values map[T]any
+	// mu guards the sorted entries, which lookups build concurrently; they are up
+	// to date while no entry is pending and none went stale since they were sorted.
+	mu      sync.Mutex
+	sorted  []indexed[K, T]
+	pending []indexed[K, T]
+	stale   bool
+}
+
+type indexed[K cmp.Ordered, T element[K]] struct {
+	value any
+	item  T
+}
+
+func newIndex[K cmp.Ordered, T element[K]](kind IndexKind, ordering Ordering) *index[K, T] {
+	return &index[K, T]{
+		kind:     kind,
+		ordering: ordering,
+		hashed:   make(groups[any, K, T]),
+		values:   make(map[T]any),
+	}
+}
+
+func (x *index[K, T]) accepts(value any) error {
+	if x.kind == OrderedIndex && !orderable(value) {
+		return ErrUnorderedValue
+	}
+	if !hashable(value) {
+		return ErrUncomparableValue
+	}
+	return nil
+}
+
+// add indexes an item; ordered indexes append it to their sorted entries when it
+// sorts last and buffer it otherwise.
+func (x *index[K, T]) add(value any, item T) {
+	if x.kind == HashIndex {
+		x.hashed.add(value, item.Id(), item, item.serial(), x.ordering)
+		return
+	}
+	x.values[item] = value
+	e := indexed[K, T]{value: value, item: item}
+	if n := len(x.sorted); !x.stale && len(x.pending) == 0 && (n == 0 || compareIndexed(x.sorted[n-1], e) < 0) {
+		x.sorted = append(x.sorted, e)
+		return
+	}
+	x.pending = append(x.pending, e)
+}
+
+func (x *index[K, T]) remove(value any, item T) {
+	if x.kind == HashIndex {
+		x.hashed.remove(value, item.Id())
+		return
+	}
+	delete(x.values, item)
+	x.stale = true
+}
+
+// load indexes the given property of every item at once.
+func (x *index[K, T]) load(property string, items iter.Seq[T]) error {
+	for item := range items {
+		value, ok := item.propertyMap()[property]
+		if !ok {
+			continue
+		}
+		if err := x.accepts(value); err != nil {
+			return fmt.Errorf("invalid property '%s' of '%v': %w", property, item.Id(), err)
+		}
+		x.add(value, item)
+	}
+	return nil
+}
+
+// ordered returns the live entries of an ordered index sorted by value, then by
+// ID, sorting the pending ones in and dropping the stale ones first.
+func (x *index[K, T]) ordered() []indexed[K, T] {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if len(x.pending) > 0 || x.stale {
+		sorted := slices.DeleteFunc(slices.Concat(x.sorted, x.pending), func(e indexed[K, T]) bool {
+			value, ok := x.values[e.item]
+			return !ok || value != e.value
+		})
+		slices.SortFunc(sorted, compareIndexed)
+		x.sorted = slices.CompactFunc(sorted, func(a, b indexed[K, T]) bool {
+			return a.item == b.item && compareIndexed(a, b) == 0
+		})
+		x.pending, x.stale = nil, false
+	}
+	return x.sorted
+}
+
+func (x *index[K, T]) equal(value any) (items []T) {
+	if x.kind == HashIndex {
+		if hashable(value) {
+			items = slices.Collect(x.hashed[value].values())
+		}
+		return
+	}
+	if !orderable(value) {
+		return
+	}
+	sorted := x.ordered()
+	for _, e := range sorted[lowerBound(sorted, value):] {
+		if compareValues(e.value, value) != 0 {
+			break
+		}
+		if e.value == value {
+			items = append(items, e.item)
+		}
+	}
+	return
+}
+
+// between returns the items whose value lies in [from, to), in value order; a
+// nil bound leaves that side open. Only ordered indexes support it.
+func (x *index[K, T]) between(from, to any) (items []T) {
+	sorted := x.ordered()
+	i := 0
+	if from != nil {
+		i = lowerBound(sorted, from)
+	}
+	for _, e := range sorted[i:] {
+		if to != nil && compareValues(e.value, to) >= 0 {
+			break
+		}
+		items = append(items, e.item)
+	}
+	return
+}
+
+func lowerBound[K cmp.Ordered, T element[K]](sorted []indexed[K, T], value any) int {
+	return sort.Search(len(sorted), func(i int) bool {
+		return compareValues(sorted[i].value, value) >= 0
+	})
+}
+
+func compareIndexed[K cmp.Ordered, T element[K]](a, b indexed[K, T]) int {
+	if c := compareValues(a.value, b.value); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.item.Id(), b.item.Id())
+}
+
+// indexes holds the declared indexes of vertex or edge properties by property.
+type indexes[K cmp.Ordered, T element[K]] map[string]*index[K, T]
+
+// check reports whether every indexed property of the given ones is accepted.
+func (xs indexes[K, T]) check(properties map[string]any) error {
+	for property, x := range xs {
+		if value, ok := properties[property]; ok {
+			if err := x.accepts(value); err != nil {
+				return fmt.Errorf("invalid property '%s': %w", property, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (xs indexes[K, T]) insert(item T) {
+	for property, x := range xs {
+		if value, ok := item.propertyMap()[property]; ok {
+			x.add(value, item)
+		}
+	}
+}
+
+func (xs indexes[K, T]) delete(item T) {
+	for property, x := range xs {
+		if value, ok := item.propertyMap()[property]; ok {
+			x.remove(value, item)
+		}
+	}
+}
+
+// update reindexes an item whose property is about to be set to the given value,
+// or removed.
+func (xs indexes[K, T]) update(item T, property string, value any, removed bool) error {
+	x, ok := xs[property]
+	if !ok {
+		return nil
+	}
+	if !removed {
+		if err := x.accepts(value); err != nil {
+			return fmt.Errorf("invalid property '%s': %w", property, err)
+		}
+	}
+	if old, ok := item.propertyMap()[property]; ok {
+		x.remove(old, item)
+	}
+	if !removed {
+		x.add(value, item)
+	}
+	return nil
+}
+
+func (xs indexes[K, T]) clone(ordering Ordering, items iter.Seq[T]) indexes[K, T] {
+	clone := make(indexes[K, T], len(xs))
+	for property, x := range xs {
+		clone[property] = newIndex[K, T](x.kind, ordering)
+		_ = clone[property].load(property, items)
+	}
+	return clone
+}
+
+// lookup finds the items holding the given value, through the index of the
+// property when there is one.
+func lookup[K cmp.Ordered, T element[K]](xs indexes[K, T], items iter.Seq[T], property string, value any) []T {
+	if x, ok := xs[property]; ok {
+		return x.equal(value)
+	}
+	var found []T
+	if !hashable(value) {
+		return found
+	}
+	for item := range items {
+		if v, ok := item.propertyMap()[property]; ok && hashable(v) && v == value {
+			found = append(found, item)
+		}
+	}
+	return found
+}
+
+// lookupRange finds the items whose value lies in [from, to), sorted by value,
+// then by ID, through the index of the property when it is ordered.
+func lookupRange[K cmp.Ordered, T element[K]](xs indexes[K, T], items iter.Seq[T], property string, from, to any) ([]T, error) {
+	for _, bound := range []any{from, to} {
+		if bound != nil && !orderable(bound) {
+			return nil, fmt.Errorf("invalid bound '%v': %w", bound, ErrUnorderedValue)
+		}
+	}
+	x, ok := xs[property]
+	if !ok || x.kind != OrderedIndex {
+		x = newIndex[K, T](OrderedIndex, Unordered)
+		for item := range items {
+			if v, ok := item.propertyMap()[property]; ok && orderable(v) {
+				x.add(v, item)
+			}
+		}
+	}
+	return x.between(from, to), nil
+}
+
+// hashable checks the value itself rather than its type, as comparing structs or
+// arrays panics when their interface fields hold slices, maps or functions.
+func hashable(value any) bool {
+	return value == nil || reflect.ValueOf(value).Comparable()
+}
+
+func orderable(value any) bool {
+	return orderRank(reflect.ValueOf(value)) >= 0
+}
+
+func orderRank(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Bool:
+		return 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return 1
+	case reflect.String:
+		return 2
+	}
+	return -1
+}
+
+// compareValues orders two orderable values: booleans before numbers before
+// strings, numbers by value whatever their types.
+func compareValues(a, b any) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra, rb := orderRank(va), orderRank(vb); ra != rb {
+		return cmp.Compare(ra, rb)
+	}
+	switch va.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(boolRank(va.Bool()), boolRank(vb.Bool()))
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	}
+	return compareNumbers(va, vb)
+}
+
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case a.CanInt() && b.CanInt():
+		return cmp.Compare(a.Int(), b.Int())
+	case a.CanUint() && b.CanUint():
+		return cmp.Compare(a.Uint(), b.Uint())
+	case a.CanInt() && b.CanUint():
+		if a.Int() < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(a.Int()), b.Uint())
+	case a.CanUint() && b.CanInt():
+		return -compareNumbers(b, a)
+	}
+	return cmp.Compare(number(a), number(b))
+}
+
+func number(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
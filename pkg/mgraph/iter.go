@@ -0,0 +1,198 @@
+package mgraph
+
+import "iter"
+
+func (g *graph[V, E]) AllVertices() iter.Seq[TypedVertex[V, E]] {
+	return func(yield func(TypedVertex[V, E]) bool) {
+		if g.mu.concurrent() {
+			for _, v := range g.Vertices() {
+				if !yield(v) {
+					return
+				}
+			}
+			return
+		}
+		for v := range g.vertices.values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (g *graph[V, E]) VertexEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return func(yield func(VertexID, TypedVertex[V, E]) bool) {
+		if g.mu.concurrent() {
+			for _, v := range g.Vertices() {
+				if !yield(v.Id(), v) {
+					return
+				}
+			}
+			return
+		}
+		for id, v := range g.vertices.all() {
+			if !yield(id, v) {
+				return
+			}
+		}
+	}
+}
+
+func (g *graph[V, E]) AllEdges() iter.Seq[TypedEdge[V, E]] {
+	return func(yield func(TypedEdge[V, E]) bool) {
+		if g.mu.concurrent() {
+			for _, e := range g.Edges() {
+				if !yield(e) {
+					return
+				}
+			}
+			return
+		}
+		for e := range g.edges.values() {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func (g *graph[V, E]) EdgeEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return func(yield func(EdgeID, TypedEdge[V, E]) bool) {
+		if g.mu.concurrent() {
+			for _, e := range g.Edges() {
+				if !yield(e.Id(), e) {
+					return
+				}
+			}
+			return
+		}
+		for id, e := range g.edges.all() {
+			if !yield(id, e) {
+				return
+			}
+		}
+	}
+}
+
+func (v *vertex[V, E]) In() iter.Seq[TypedEdge[V, E]] {
+	return values(v.InEntries())
+}
+
+func (v *vertex[V, E]) InEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.adjacency(v.Incoming, func(g *graph[V, E]) groups[VertexID, EdgeID, *edge[V, E]] { return g.edgesTo })
+}
+
+func (v *vertex[V, E]) Out() iter.Seq[TypedEdge[V, E]] {
+	return values(v.OutEntries())
+}
+
+func (v *vertex[V, E]) OutEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.adjacency(v.Outgoing, func(g *graph[V, E]) groups[VertexID, EdgeID, *edge[V, E]] { return g.edgesFrom })
+}
+
+func (v *vertex[V, E]) Neighbors() iter.Seq[TypedVertex[V, E]] {
+	return values(v.NeighborEntries())
+}
+
+// NeighborEntries yields every distinct vertex joined to v by an edge in either
+// direction, including v itself when it has a loop.
+func (v *vertex[V, E]) NeighborEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return func(yield func(VertexID, TypedVertex[V, E]) bool) {
+		if g := v.Graph(); g != nil {
+			neighborEntries(g, v.id, v.OutEntries(), v.InEntries())(yield)
+		}
+	}
+}
+
+func neighborEntries[V, E any](g TypedView[V, E], id VertexID, out, in iter.Seq2[EdgeID, TypedEdge[V, E]]) iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return func(yield func(VertexID, TypedVertex[V, E]) bool) {
+		seen := map[VertexID]bool{}
+		for _, edges := range [2]iter.Seq2[EdgeID, TypedEdge[V, E]]{out, in} {
+			for _, e := range edges {
+				n := opposite(e, id)
+				if seen[n] {
+					continue
+				}
+				seen[n] = true
+				if v := g.Vertex(n); v != nil && !yield(n, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// adjacency iterates the live adjacency map of a vertex, or a snapshot of it on
+// concurrent graphs so that yield can freely call back into the graph.
+func (v *vertex[V, E]) adjacency(snapshot func() []TypedEdge[V, E], live func(g *graph[V, E]) groups[VertexID, EdgeID, *edge[V, E]]) iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return func(yield func(EdgeID, TypedEdge[V, E]) bool) {
+		if v.mu.concurrent() {
+			for _, e := range snapshot() {
+				if !yield(e.Id(), e) {
+					return
+				}
+			}
+			return
+		}
+		if v.graph == nil {
+			return
+		}
+		for id, e := range live(v.graph)[v.id].all() {
+			if !yield(id, e) {
+				return
+			}
+		}
+	}
+}
+
+func values[K, T any](seq iter.Seq2[K, T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range seq {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// wrapVertices wraps every vertex of a view, dropping the ones wrap hides by
+// returning nil.
+func wrapVertices[V, E any](vertices []TypedVertex[V, E], wrap func(v TypedVertex[V, E]) TypedVertex[V, E]) []TypedVertex[V, E] {
+	wrapped := make([]TypedVertex[V, E], 0, len(vertices))
+	for _, v := range vertices {
+		if v := wrap(v); v != nil {
+			wrapped = append(wrapped, v)
+		}
+	}
+	return wrapped
+}
+
+func wrapEdges[V, E any](edges []TypedEdge[V, E], wrap func(e TypedEdge[V, E]) TypedEdge[V, E]) []TypedEdge[V, E] {
+	wrapped := make([]TypedEdge[V, E], 0, len(edges))
+	for _, e := range edges {
+		if e := wrap(e); e != nil {
+			wrapped = append(wrapped, e)
+		}
+	}
+	return wrapped
+}
+
+func wrapVertexEntries[V, E any](entries iter.Seq2[VertexID, TypedVertex[V, E]], wrap func(v TypedVertex[V, E]) TypedVertex[V, E]) iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return func(yield func(VertexID, TypedVertex[V, E]) bool) {
+		for id, v := range entries {
+			if v := wrap(v); v != nil && !yield(id, v) {
+				return
+			}
+		}
+	}
+}
+
+func wrapEdgeEntries[V, E any](entries iter.Seq2[EdgeID, TypedEdge[V, E]], wrap func(e TypedEdge[V, E]) TypedEdge[V, E]) iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return func(yield func(EdgeID, TypedEdge[V, E]) bool) {
+		for id, e := range entries {
+			if e := wrap(e); e != nil && !yield(id, e) {
+				return
+			}
+		}
+	}
+}
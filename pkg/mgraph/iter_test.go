@@ -0,0 +1,140 @@
+package mgraph
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func newIterGraph() Graph {
+	g := New()
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddVertex("node3")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	_, _ = g.AddEdge("edge2", "node1", "node3")
+	_, _ = g.AddEdge("edge3", "node3", "node1")
+	_, _ = g.AddEdge("edge4", "node1", "node2")
+	return g
+}
+
+func TestGraph_AllVertices(t *testing.T) {
+	g := newIterGraph()
+	visited := map[VertexID]bool{}
+	for v := range g.AllVertices() {
+		visited[v.Id()] = true
+	}
+	if len(visited) != 3 {
+		t.Fatalf("AllVertices() expected to yield 3 vertices, yielded: %v", visited)
+	}
+	count := 0
+	for range g.AllVertices() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("AllVertices() expected to stop after break, yielded: %d", count)
+	}
+}
+
+func TestGraph_VertexEntries(t *testing.T) {
+	g := newIterGraph()
+	for id, v := range g.VertexEntries() {
+		if v.Id() != id {
+			t.Fatalf("VertexEntries() expected id %s, got: %s", v.Id(), id)
+		}
+	}
+}
+
+func TestGraph_AllEdgesAndEdgeEntries(t *testing.T) {
+	g := newIterGraph()
+	visited := map[EdgeID]bool{}
+	for e := range g.AllEdges() {
+		visited[e.Id()] = true
+	}
+	if len(visited) != 4 {
+		t.Fatalf("AllEdges() expected to yield 4 edges, yielded: %v", visited)
+	}
+	for id, e := range g.EdgeEntries() {
+		if e.Id() != id {
+			t.Fatalf("EdgeEntries() expected id %s, got: %s", e.Id(), id)
+		}
+	}
+}
+
+func TestGraph_AllVertices_DoesNotAllocatePerElement(t *testing.T) {
+	iterate := func(g Graph) float64 {
+		return testing.AllocsPerRun(100, func() {
+			for v := range g.AllVertices() {
+				_ = v
+			}
+			for e := range g.AllEdges() {
+				_ = e
+			}
+		})
+	}
+	small, large := newIterGraph(), newIterGraph()
+	for i := 0; i < 1000; i++ {
+		_, _ = large.AddVertex(VertexID(fmt.Sprint(i)))
+		_, _ = large.AddEdge(EdgeID(fmt.Sprint(i)), "node1", VertexID(fmt.Sprint(i)))
+	}
+	if iterate(small) != iterate(large) {
+		t.Fatalf("AllVertices() expected allocations independent of the graph size, got: %v and %v", iterate(small), iterate(large))
+	}
+}
+
+func TestVertex_InAndOut(t *testing.T) {
+	g := newIterGraph()
+	v := g.Vertex("node1")
+	var in, out []EdgeID
+	for e := range v.In() {
+		in = append(in, e.Id())
+	}
+	for id := range v.OutEntries() {
+		out = append(out, id)
+	}
+	slices.Sort(out)
+	if !slices.Equal(in, []EdgeID{"edge3"}) {
+		t.Fatalf("In() expected [edge3], got: %v", in)
+	}
+	if !slices.Equal(out, []EdgeID{"edge1", "edge2", "edge4"}) {
+		t.Fatalf("OutEntries() expected [edge1 edge2 edge4], got: %v", out)
+	}
+}
+
+func TestVertex_Neighbors(t *testing.T) {
+	g := newIterGraph()
+	_, _ = g.AddEdge("edge5", "node2", "node2")
+	var neighbors []VertexID
+	for v := range g.Vertex("node1").Neighbors() {
+		neighbors = append(neighbors, v.Id())
+	}
+	slices.Sort(neighbors)
+	if !slices.Equal(neighbors, []VertexID{"node2", "node3"}) {
+		t.Fatalf("Neighbors() expected [node2 node3], got: %v", neighbors)
+	}
+	neighbors = nil
+	for id := range g.Vertex("node2").NeighborEntries() {
+		neighbors = append(neighbors, id)
+	}
+	slices.Sort(neighbors)
+	if !slices.Equal(neighbors, []VertexID{"node1", "node2"}) {
+		t.Fatalf("NeighborEntries() expected [node1 node2], got: %v", neighbors)
+	}
+}
+
+func TestConcurrentGraph_IteratorsAllowMutation(t *testing.T) {
+	g := NewConcurrent()
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	for e := range g.Vertex("node1").Out() {
+		g.RemoveEdge(e.Id())
+	}
+	for v := range g.AllVertices() {
+		g.RemoveVertex(v.Id())
+	}
+	if g.Order() != 0 || g.Size() != 0 {
+		t.Fatalf("AllVertices() expected to allow mutations, got: %d vertices %d edges", g.Order(), g.Size())
+	}
+}
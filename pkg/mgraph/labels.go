@@ -0,0 +1,148 @@
+package mgraph
+
+import (
+	"maps"
+	"slices"
+)
+
+func (g *graph[V, E]) VerticesByLabel(label string) []TypedVertex[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c := g.vertexLabels[label]
+	vertices := make([]TypedVertex[V, E], 0, c.len())
+	for v := range c.values() {
+		vertices = append(vertices, v)
+	}
+	return vertices
+}
+
+func (g *graph[V, E]) EdgesByLabel(label string) []TypedEdge[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	c := g.edgeLabels[label]
+	edges := make([]TypedEdge[V, E], 0, c.len())
+	for e := range c.values() {
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// EdgesBetweenByLabel takes time in the number of edges of the label leaving from.
+// Unlabeled edges are not indexed by endpoint, so the empty label scans every edge
+// leaving from.
+func (g *graph[V, E]) EdgesBetweenByLabel(from, to VertexID, label string) (edges []TypedEdge[V, E]) {
+	if label == "" {
+		return slices.DeleteFunc(g.EdgesBetween(from, to), func(e TypedEdge[V, E]) bool {
+			return e.Label() != label
+		})
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for e := range g.labeledFrom[labeledVertex{from, label}].values() {
+		if opposite[V, E](e, from) == to {
+			edges = append(edges, e)
+		}
+	}
+	return
+}
+
+func (g *graph[V, E]) OrderByLabel(label string) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.vertexLabels[label].len()
+}
+
+func (g *graph[V, E]) SizeByLabel(label string) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edgeLabels[label].len()
+}
+
+func (g *graph[V, E]) VertexLabels() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Sorted(maps.Keys(g.vertexLabels))
+}
+
+func (g *graph[V, E]) EdgeLabels() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Sorted(maps.Keys(g.edgeLabels))
+}
+
+func (g *graph[V, E]) labelVertex(v *vertex[V, E]) {
+	if v.label != "" {
+		g.vertexLabels.add(v.label, v.id, v, v.seq, g.properties.ordering)
+	}
+}
+
+// labeledVertex groups the edges of a vertex by label, so that looking them up
+// does not go through the whole adjacency of the vertex.
+type labeledVertex struct {
+	id    VertexID
+	label string
+}
+
+// labelEdge indexes a labeled edge by its label, and by its label at each of its
+// endpoints the way link does.
+func (g *graph[V, E]) labelEdge(e *edge[V, E]) {
+	if e.label == "" {
+		return
+	}
+	g.edgeLabels.add(e.label, e.id, e, e.seq, g.properties.ordering)
+	g.labeledFrom.add(labeledVertex{e.from, e.label}, e.id, e, e.seq, g.properties.ordering)
+	g.labeledTo.add(labeledVertex{e.to, e.label}, e.id, e, e.seq, g.properties.ordering)
+	if !e.directed && !e.IsLoop() {
+		g.labeledFrom.add(labeledVertex{e.to, e.label}, e.id, e, e.seq, g.properties.ordering)
+		g.labeledTo.add(labeledVertex{e.from, e.label}, e.id, e, e.seq, g.properties.ordering)
+	}
+}
+
+func (g *graph[V, E]) unlabelEdge(e *edge[V, E]) {
+	if e.label == "" {
+		return
+	}
+	g.edgeLabels.remove(e.label, e.id)
+	for _, v := range [2]VertexID{e.from, e.to} {
+		g.labeledFrom.remove(labeledVertex{v, e.label}, e.id)
+		g.labeledTo.remove(labeledVertex{v, e.label}, e.id)
+	}
+}
+
+// IncomingByLabel takes time in the number of incoming edges of the label, or in
+// the number of incoming edges for the empty label, whose edges are not indexed.
+func (v *vertex[V, E]) IncomingByLabel(label string) []TypedEdge[V, E] {
+	if label == "" {
+		return slices.DeleteFunc(v.Incoming(), func(e TypedEdge[V, E]) bool {
+			return e.Label() != label
+		})
+	}
+	return v.edgesByLabel(label, false)
+}
+
+// OutgoingByLabel takes time in the number of outgoing edges of the label, or in
+// the number of outgoing edges for the empty label, whose edges are not indexed.
+func (v *vertex[V, E]) OutgoingByLabel(label string) []TypedEdge[V, E] {
+	if label == "" {
+		return slices.DeleteFunc(v.Outgoing(), func(e TypedEdge[V, E]) bool {
+			return e.Label() != label
+		})
+	}
+	return v.edgesByLabel(label, true)
+}
+
+func (v *vertex[V, E]) edgesByLabel(label string, outgoing bool) (edges []TypedEdge[V, E]) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.graph == nil {
+		return
+	}
+	adjacency := v.graph.labeledTo
+	if outgoing {
+		adjacency = v.graph.labeledFrom
+	}
+	for e := range adjacency[labeledVertex{v.id, label}].values() {
+		edges = append(edges, e)
+	}
+	return
+}
@@ -0,0 +1,134 @@
+package mgraph
+
+import (
+	"slices"
+	"testing"
+)
+
+func newCatalogGraph() Graph {
+	g := New(WithOrdering(SortedByID))
+	g.EnsureConsistency(true)
+	_, _ = g.AddVertex("alice", WithLabel("user"))
+	_, _ = g.AddVertex("bob", WithLabel("user"))
+	_, _ = g.AddVertex("alien", WithLabel("film"))
+	_, _ = g.AddVertex("heat", WithLabel("film"))
+	_, _ = g.AddVertex("thriller", WithLabel("genre"))
+	_, _ = g.AddVertex("unknown")
+	_, _ = g.AddEdge("e1", "alice", "alien", WithLabel("viewed"))
+	_, _ = g.AddEdge("e2", "alice", "heat", WithLabel("viewed"))
+	_, _ = g.AddEdge("e3", "alice", "bob", WithLabel("isFriendOf"))
+	_, _ = g.AddEdge("e4", "alien", "thriller", WithLabel("isOfGenre"))
+	_, _ = g.AddEdge("e5", "alice", "alien", WithLabel("rated"))
+	_, _ = g.AddEdge("e6", "bob", "unknown")
+	return g
+}
+
+func vertexIDs[V, E any](vertices []TypedVertex[V, E]) []VertexID {
+	ids := make([]VertexID, 0, len(vertices))
+	for _, v := range vertices {
+		ids = append(ids, v.Id())
+	}
+	return ids
+}
+
+func TestGraph_VerticesByLabel(t *testing.T) {
+	g := newCatalogGraph()
+	if ids := vertexIDs(g.VerticesByLabel("film")); !slices.Equal(ids, []VertexID{"alien", "heat"}) {
+		t.Fatalf("VerticesByLabel() expected [alien heat], got: %v", ids)
+	}
+	if vertices := g.VerticesByLabel("series"); len(vertices) != 0 {
+		t.Fatalf("VerticesByLabel() expected no vertices, got: %v", vertexIDs(vertices))
+	}
+	if label := g.Vertex("alice").Label(); label != "user" {
+		t.Fatalf("Label() expected user, got: %s", label)
+	}
+	if label := g.Vertex("unknown").Label(); label != "" {
+		t.Fatalf("Label() expected empty label, got: %s", label)
+	}
+}
+
+func TestGraph_EdgesByLabel(t *testing.T) {
+	g := newCatalogGraph()
+	if ids := edgeIDs(g.EdgesByLabel("viewed")); !slices.Equal(ids, []EdgeID{"e1", "e2"}) {
+		t.Fatalf("EdgesByLabel() expected [e1 e2], got: %v", ids)
+	}
+	if ids := edgeIDs(g.EdgesBetweenByLabel("alice", "alien", "viewed")); !slices.Equal(ids, []EdgeID{"e1"}) {
+		t.Fatalf("EdgesBetweenByLabel() expected [e1], got: %v", ids)
+	}
+	if ids := edgeIDs(g.Vertex("alice").OutgoingByLabel("viewed")); !slices.Equal(ids, []EdgeID{"e1", "e2"}) {
+		t.Fatalf("OutgoingByLabel() expected [e1 e2], got: %v", ids)
+	}
+	if ids := edgeIDs(g.Vertex("alien").IncomingByLabel("viewed")); !slices.Equal(ids, []EdgeID{"e1"}) {
+		t.Fatalf("IncomingByLabel() expected [e1], got: %v", ids)
+	}
+}
+
+func TestGraph_CountsByLabel(t *testing.T) {
+	g := newCatalogGraph()
+	if n := g.OrderByLabel("film"); n != 2 {
+		t.Fatalf("OrderByLabel() expected 2, got: %d", n)
+	}
+	if n := g.SizeByLabel("viewed"); n != 2 {
+		t.Fatalf("SizeByLabel() expected 2, got: %d", n)
+	}
+	if labels := g.VertexLabels(); !slices.Equal(labels, []string{"film", "genre", "user"}) {
+		t.Fatalf("VertexLabels() expected [film genre user], got: %v", labels)
+	}
+	if labels := g.EdgeLabels(); !slices.Equal(labels, []string{"isFriendOf", "isOfGenre", "rated", "viewed"}) {
+		t.Fatalf("EdgeLabels() expected [isFriendOf isOfGenre rated viewed], got: %v", labels)
+	}
+}
+
+func TestGraph_RemoveVertex_UpdatesLabelIndexes(t *testing.T) {
+	g := newCatalogGraph()
+	g.RemoveVertex("alien")
+	if n := g.OrderByLabel("film"); n != 1 {
+		t.Fatalf("OrderByLabel() expected 1, got: %d", n)
+	}
+	if ids := edgeIDs(g.EdgesByLabel("viewed")); !slices.Equal(ids, []EdgeID{"e2"}) {
+		t.Fatalf("EdgesByLabel() expected [e2], got: %v", ids)
+	}
+	if labels := g.EdgeLabels(); !slices.Equal(labels, []string{"isFriendOf", "viewed"}) {
+		t.Fatalf("EdgeLabels() expected [isFriendOf viewed], got: %v", labels)
+	}
+	g.RemoveEdge("e2")
+	if n := g.SizeByLabel("viewed"); n != 0 {
+		t.Fatalf("SizeByLabel() expected 0, got: %d", n)
+	}
+}
+
+func TestGraph_Clone_KeepsLabels(t *testing.T) {
+	g := newCatalogGraph()
+	clone := g.Clone()
+	g.RemoveVertex("heat")
+	if ids := vertexIDs(clone.VerticesByLabel("film")); !slices.Equal(ids, []VertexID{"alien", "heat"}) {
+		t.Fatalf("VerticesByLabel() expected [alien heat] on clone, got: %v", ids)
+	}
+	if label := clone.Edge("e3").Label(); label != "isFriendOf" {
+		t.Fatalf("Label() expected isFriendOf on clone, got: %s", label)
+	}
+}
+
+func TestGraph_LabeledAdjacency(t *testing.T) {
+	g := New(WithDirectedness(Undirected), WithOrdering(SortedByID))
+	for _, id := range []VertexID{"a", "b", "c"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("e1", "a", "b", WithLabel("knows"))
+	_, _ = g.AddEdge("e2", "a", "b")
+	_, _ = g.AddEdge("e3", "c", "a", WithLabel("knows"))
+	if ids := edgeIDs(g.EdgesBetweenByLabel("b", "a", "knows")); !slices.Equal(ids, []EdgeID{"e1"}) {
+		t.Fatalf("EdgesBetweenByLabel() expected [e1] both ways on undirected edges, got: %v", ids)
+	}
+	if ids := edgeIDs(g.Vertex("a").OutgoingByLabel("")); !slices.Equal(ids, []EdgeID{"e2"}) {
+		t.Fatalf("OutgoingByLabel() expected [e2] for the empty label, got: %v", ids)
+	}
+	clone := g.Clone()
+	g.RemoveEdge("e1")
+	if ids := edgeIDs(g.Vertex("a").IncomingByLabel("knows")); !slices.Equal(ids, []EdgeID{"e3"}) {
+		t.Fatalf("IncomingByLabel() expected [e3], got: %v", ids)
+	}
+	if ids := edgeIDs(clone.Vertex("b").OutgoingByLabel("knows")); !slices.Equal(ids, []EdgeID{"e1"}) {
+		t.Fatalf("OutgoingByLabel() expected [e1] on clone, got: %v", ids)
+	}
+}
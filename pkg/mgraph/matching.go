@@ -0,0 +1,272 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var ErrNotBipartite = errors.New("graph is not bipartite")
+
+// IsBipartite colors the vertices of every component alternately, following edges
+// whatever their direction. It returns the two sides, the first one holding the
+// first vertex of every component in the graph order, or else the edges of an odd
+// cycle in path order. Self-loops are odd cycles of a single edge.
+func IsBipartite[V, E any](g TypedView[V, E]) (sides [2][]VertexID, oddCycle []EdgeID, ok bool) {
+	side := make(map[VertexID]int, g.Order())
+	depth := make(map[VertexID]int, g.Order())
+	parent := map[VertexID]TypedEdge[V, E]{}
+	for root := range g.AllVertices() {
+		if _, ok := side[root.Id()]; ok {
+			continue
+		}
+		side[root.Id()] = 0
+		queue := []TypedVertex[V, E]{root}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, e := range v.Edges() {
+				next := opposite(e, v.Id())
+				s, seen := side[next]
+				if seen && s == side[v.Id()] {
+					return [2][]VertexID{}, oddCycleOf(e, v.Id(), next, parent, depth), false
+				}
+				if seen {
+					continue
+				}
+				n := g.Vertex(next)
+				if n == nil {
+					continue
+				}
+				side[next] = 1 - side[v.Id()]
+				depth[next] = depth[v.Id()] + 1
+				parent[next] = e
+				queue = append(queue, n)
+			}
+		}
+	}
+	sides = [2][]VertexID{{}, {}}
+	for v := range g.AllVertices() {
+		sides[side[v.Id()]] = append(sides[side[v.Id()]], v.Id())
+	}
+	return sides, nil, true
+}
+
+// oddCycleOf closes the cycle made by an edge between two vertices of the same BFS
+// tree, from their closest common ancestor.
+func oddCycleOf[V, E any](e TypedEdge[V, E], a, b VertexID, parent map[VertexID]TypedEdge[V, E], depth map[VertexID]int) []EdgeID {
+	var up, down []EdgeID
+	for a != b {
+		if depth[a] >= depth[b] {
+			up = append(up, parent[a].Id())
+			a = opposite(parent[a], a)
+		} else {
+			down = append(down, parent[b].Id())
+			b = opposite(parent[b], b)
+		}
+	}
+	slices.Reverse(up)
+	return append(append(up, e.Id()), down...)
+}
+
+// HopcroftKarp returns the edges of a maximum cardinality matching of a bipartite
+// graph, whatever their direction. Among parallel edges, the first one is matched.
+func HopcroftKarp[V, E any](g TypedView[V, E]) ([]EdgeID, error) {
+	b, err := newBipartiteGraph(g, nil)
+	if err != nil {
+		return nil, err
+	}
+	left, right := len(b.left), len(b.right)
+	matchLeft := make([]int, left)
+	matchRight := make([]int, right)
+	for i := range matchLeft {
+		matchLeft[i] = -1
+	}
+	for j := range matchRight {
+		matchRight[j] = -1
+	}
+	dist := make([]int, left)
+	// layers computes the distance of every left vertex from the unmatched ones
+	// along alternating paths, and reports whether an augmenting path exists.
+	layers := func() bool {
+		var queue []int
+		for i := range dist {
+			dist[i] = -1
+			if matchLeft[i] < 0 {
+				dist[i] = 0
+				queue = append(queue, i)
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			i := queue[0]
+			queue = queue[1:]
+			for _, j := range b.adj[i] {
+				k := matchRight[j]
+				if k < 0 {
+					found = true
+				} else if dist[k] < 0 {
+					dist[k] = dist[i] + 1
+					queue = append(queue, k)
+				}
+			}
+		}
+		return found
+	}
+	var augment func(i int) bool
+	augment = func(i int) bool {
+		for _, j := range b.adj[i] {
+			if k := matchRight[j]; k < 0 || dist[k] == dist[i]+1 && augment(k) {
+				matchLeft[i], matchRight[j] = j, i
+				return true
+			}
+		}
+		dist[i] = -1
+		return false
+	}
+	for layers() {
+		for i := range matchLeft {
+			if matchLeft[i] < 0 {
+				augment(i)
+			}
+		}
+	}
+	matching := []EdgeID{}
+	for i, j := range matchLeft {
+		if j >= 0 {
+			matching = append(matching, b.edges[[2]int{i, j}].Id())
+		}
+	}
+	return matching, nil
+}
+
+// Hungarian returns the edges of a minimum weight assignment of a bipartite graph:
+// among the matchings of maximum cardinality, the one of minimum total weight, along
+// with that weight. Negate the weights to maximize them. Among parallel edges, the
+// lightest one is considered.
+func Hungarian[V, E any](g TypedView[V, E], weigher Weigher[V, E]) ([]EdgeID, float64, error) {
+	b, err := newBipartiteGraph(g, weigher)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, cols := b.left, b.right
+	transposed := len(rows) > len(cols)
+	if transposed {
+		rows, cols = cols, rows
+	}
+	n, m := len(rows), len(cols)
+	// Missing edges cost more than any matching, so that as many rows as possible
+	// are matched through edges.
+	missing := 1.0
+	for _, e := range b.edges {
+		missing += math.Abs(weigher.weight(e))
+	}
+	cost := func(i, j int) float64 {
+		key := [2]int{i, j}
+		if transposed {
+			key = [2]int{j, i}
+		}
+		if e, ok := b.edges[key]; ok {
+			return weigher.weight(e)
+		}
+		return missing
+	}
+	// Shortest augmenting paths with potentials u and v over a 1-based n x m matrix,
+	// where p[j] is the row assigned to column j and column 0 stands for the new row.
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if c := cost(i0-1, j-1) - u[i0] - v[j]; c < minv[j] {
+					minv[j], way[j] = c, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	assignment := []EdgeID{}
+	var total float64
+	for j := 1; j <= m; j++ {
+		if p[j] == 0 {
+			continue
+		}
+		key := [2]int{p[j] - 1, j - 1}
+		if transposed {
+			key = [2]int{j - 1, p[j] - 1}
+		}
+		if e, ok := b.edges[key]; ok {
+			assignment = append(assignment, e.Id())
+			total += weigher.weight(e)
+		}
+	}
+	return assignment, total, nil
+}
+
+// bipartiteGraph indexes the sides of a bipartite graph and keeps a single edge
+// between each pair of vertices: the first one, or the lightest one with a weigher.
+type bipartiteGraph[V, E any] struct {
+	left, right []VertexID
+	adj         [][]int
+	edges       map[[2]int]TypedEdge[V, E]
+}
+
+func newBipartiteGraph[V, E any](g TypedView[V, E], weigher Weigher[V, E]) (*bipartiteGraph[V, E], error) {
+	sides, cycle, ok := IsBipartite(g)
+	if !ok {
+		return nil, fmt.Errorf("error while matching: %w: odd cycle %v", ErrNotBipartite, cycle)
+	}
+	b := &bipartiteGraph[V, E]{left: sides[0], right: sides[1], adj: make([][]int, len(sides[0])), edges: map[[2]int]TypedEdge[V, E]{}}
+	right := make(map[VertexID]int, len(sides[1]))
+	for j, id := range sides[1] {
+		right[id] = j
+	}
+	for i, id := range sides[0] {
+		for _, e := range g.Vertex(id).Edges() {
+			j, ok := right[opposite(e, id)]
+			if !ok {
+				continue
+			}
+			key := [2]int{i, j}
+			old, ok := b.edges[key]
+			if !ok {
+				b.adj[i] = append(b.adj[i], j)
+			}
+			if !ok || weigher != nil && weigher.weight(e) < weigher.weight(old) {
+				b.edges[key] = e
+			}
+		}
+	}
+	return b, nil
+}
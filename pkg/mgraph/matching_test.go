@@ -0,0 +1,81 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func newBipartiteTestGraph() Graph {
+	g := New(WithDirectedness(Mixed), WithOrdering(InsertionOrder))
+	for _, id := range []VertexID{"u1", "f1", "u2", "u3", "f2", "f3", "u4"} {
+		_, _ = g.AddVertex(id)
+	}
+	for _, e := range []struct {
+		from, to VertexID
+		weight   float64
+	}{
+		{"u1", "f1", 3}, {"u1", "f2", 1}, {"u2", "f1", 2}, {"f2", "u2", 4},
+		{"u3", "f2", 1}, {"u3", "f3", 5}, {"u4", "f1", 1},
+	} {
+		_, _ = g.AddEdge(EdgeID(e.from+"-"+e.to), e.from, e.to, WithProperty("weight", e.weight))
+	}
+	_, _ = g.AddEdge("u1-f1'", "u1", "f1", WithProperty("weight", 2.0), UndirectedEdge())
+	return g
+}
+
+func TestIsBipartite(t *testing.T) {
+	g := newBipartiteTestGraph()
+	sides, _, ok := IsBipartite(g)
+	if !ok || !slices.Equal(sides[0], []VertexID{"u1", "u2", "u3", "u4"}) || !slices.Equal(sides[1], []VertexID{"f1", "f2", "f3"}) {
+		t.Fatalf("IsBipartite() expected the sides [u1 u2 u3 u4] [f1 f2 f3], got: %v, %v", sides, ok)
+	}
+	_, _ = g.AddEdge("u4-u2", "u4", "u2")
+	if _, cycle, ok := IsBipartite(g); ok || !slices.Equal(cycle, []EdgeID{"u2-f1", "u4-u2", "u4-f1"}) {
+		t.Fatalf("IsBipartite() expected the odd cycle [u2-f1 u4-u2 u4-f1], got: %v, %v", cycle, ok)
+	}
+	_, _ = g.AddEdge("f3-f3", "f3", "f3")
+	g.RemoveEdge("u4-u2")
+	if _, cycle, ok := IsBipartite(g); ok || !slices.Equal(cycle, []EdgeID{"f3-f3"}) {
+		t.Fatalf("IsBipartite() expected the loop as an odd cycle, got: %v, %v", cycle, ok)
+	}
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	g := newBipartiteTestGraph()
+	matching, err := HopcroftKarp(g)
+	if err != nil || len(matching) != 3 {
+		t.Fatalf("HopcroftKarp() expected 3 matched edges, got: %v, %v", matching, err)
+	}
+	matched := map[VertexID]bool{}
+	for _, id := range matching {
+		for _, v := range g.Edge(id).Endpoints() {
+			if matched[v.Id()] {
+				t.Fatalf("HopcroftKarp() expected %s to be matched once, got: %v", v.Id(), matching)
+			}
+			matched[v.Id()] = true
+		}
+	}
+	_, _ = g.AddEdge("u1-u2", "u1", "u2")
+	if _, err := HopcroftKarp(g); !errors.Is(err, ErrNotBipartite) {
+		t.Fatalf("HopcroftKarp() expected error ErrNotBipartite, got: %v", err)
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	g := newBipartiteTestGraph()
+	assignment, total, err := Hungarian(g, byWeight)
+	slices.Sort(assignment)
+	if err != nil || !slices.Equal(assignment, []EdgeID{"u1-f2", "u3-f3", "u4-f1"}) {
+		t.Fatalf("Hungarian() expected [u1-f2 u3-f3 u4-f1], got: %v, %v", assignment, err)
+	}
+	if total != 7 {
+		t.Fatalf("Hungarian() expected a total weight of 7, got: %v", total)
+	}
+	negated := func(e Edge) float64 { return -byWeight(e) }
+	assignment, total, _ = Hungarian(g, negated)
+	slices.Sort(assignment)
+	if !slices.Equal(assignment, []EdgeID{"f2-u2", "u1-f1", "u3-f3"}) || total != -12 {
+		t.Fatalf("Hungarian() expected the heaviest assignment [f2-u2 u1-f1 u3-f3], got: %v of weight %v", assignment, total)
+	}
+}
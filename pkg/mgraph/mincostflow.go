@@ -0,0 +1,221 @@
+package mgraph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrUnbalancedSupply = errors.New("supplies and demands do not balance")
+	ErrInfeasibleFlow   = errors.New("demands cannot be met")
+)
+
+// CostFlow is a flow meeting supplies and demands at minimum cost.
+type CostFlow struct {
+	Value float64
+	Cost  float64
+	// Edges maps every edge to the flow through it, from its tail to its head. The
+	// flow through an undirected edge is negative when it goes from head to tail.
+	Edges map[EdgeID]float64
+}
+
+// MinCostFlow routes the supply of every vertex, positive for sources and negative
+// for sinks, at the minimum total cost, by successive shortest paths with vertex
+// potentials. A nil cost weighs every unit of flow through an edge as 1. Costs may
+// be negative as long as they make no cycle of negative cost, which an undirected
+// edge of negative cost is on its own. Loops are ignored.
+func MinCostFlow[V, E any](g TypedView[V, E], supply map[VertexID]float64, capacity Capacity[V, E], cost Weigher[V, E]) (CostFlow, error) {
+	index := map[VertexID]int{}
+	for v := range g.AllVertices() {
+		index[v.Id()] = len(index)
+	}
+	source, sink := len(index), len(index)+1
+	n := &costNetwork{adj: make([][]int, len(index)+2)}
+	var balance, demand float64
+	for id, s := range supply {
+		if _, ok := index[id]; !ok {
+			return CostFlow{}, fmt.Errorf("error while computing min-cost flow: %w", fmt.Errorf("vertex '%s' does not exists: %w", id, ErrVertexDoesNotExists))
+		}
+		balance += s
+	}
+	for v := range g.AllVertices() {
+		if s := supply[v.Id()]; s > 0 {
+			n.add(source, index[v.Id()], s, 0)
+		} else if s < 0 {
+			n.add(index[v.Id()], sink, -s, 0)
+			demand -= s
+		}
+	}
+	if math.Abs(balance) > flowTolerance {
+		return CostFlow{}, fmt.Errorf("error while computing min-cost flow: %w: %g left over", ErrUnbalancedSupply, balance)
+	}
+	arcs := map[EdgeID][]int{}
+	for e := range g.AllEdges() {
+		from, okFrom := index[e.From()]
+		to, okTo := index[e.To()]
+		if !okFrom || !okTo || from == to {
+			continue
+		}
+		c := capacity.capacity(e)
+		if c < 0 {
+			return CostFlow{}, fmt.Errorf("error while computing min-cost flow: edge '%s': %w", e.Id(), ErrNegativeCapacity)
+		}
+		w := cost.weight(e)
+		arcs[e.Id()] = append(arcs[e.Id()], n.add(from, to, c, w))
+		if !e.IsDirected() {
+			arcs[e.Id()] = append(arcs[e.Id()], n.add(to, from, c, w))
+		}
+	}
+	potential, err := n.potentials(source)
+	if err != nil {
+		return CostFlow{}, fmt.Errorf("error while computing min-cost flow: %w", err)
+	}
+	var shipped float64
+	for shipped < demand-flowTolerance {
+		dist, via := n.shortestPaths(source, potential)
+		if via[sink] < 0 {
+			return CostFlow{}, fmt.Errorf("error while computing min-cost flow: %w: %g of %g routed", ErrInfeasibleFlow, shipped, demand)
+		}
+		for v, d := range dist {
+			if via[v] >= 0 || v == source {
+				potential[v] += d
+			}
+		}
+		amount := math.Inf(1)
+		for v := sink; v != source; v = n.arcs[via[v]^1].to {
+			amount = min(amount, n.residual(via[v]))
+		}
+		for v := sink; v != source; v = n.arcs[via[v]^1].to {
+			n.push(via[v], amount)
+		}
+		shipped += amount
+	}
+	f := CostFlow{Value: shipped, Edges: make(map[EdgeID]float64, len(arcs))}
+	for e := range g.AllEdges() {
+		var flow float64
+		for i, a := range arcs[e.Id()] {
+			if i == 0 {
+				flow += n.arcs[a].flow
+			} else {
+				flow -= n.arcs[a].flow
+			}
+			f.Cost += n.arcs[a].flow * n.arcs[a].cost
+		}
+		f.Edges[e.Id()] = flow
+	}
+	return f, nil
+}
+
+// costNetwork holds an arc a for every edge, and its opposite residual arc a^1.
+type costNetwork struct {
+	adj  [][]int
+	arcs []costArc
+}
+
+type costArc struct {
+	to       int
+	capacity float64
+	cost     float64
+	flow     float64
+}
+
+func (n *costNetwork) add(from, to int, capacity, cost float64) int {
+	a := len(n.arcs)
+	n.arcs = append(n.arcs, costArc{to: to, capacity: capacity, cost: cost}, costArc{to: from, cost: -cost})
+	n.adj[from] = append(n.adj[from], a)
+	n.adj[to] = append(n.adj[to], a^1)
+	return a
+}
+
+func (n *costNetwork) residual(a int) float64 {
+	return n.arcs[a].capacity - n.arcs[a].flow
+}
+
+func (n *costNetwork) push(a int, amount float64) {
+	n.arcs[a].flow += amount
+	n.arcs[a^1].flow -= amount
+}
+
+// potentials returns the initial vertex potentials, the Bellman-Ford distances from
+// the source, so that reduced costs are non-negative even with negative costs.
+func (n *costNetwork) potentials(source int) ([]float64, error) {
+	potential := make([]float64, len(n.adj))
+	reached := make([]bool, len(n.adj))
+	reached[source] = true
+	for i := 0; i <= len(n.adj); i++ {
+		changed := false
+		for v, arcs := range n.adj {
+			if !reached[v] {
+				continue
+			}
+			for _, a := range arcs {
+				arc := n.arcs[a]
+				if n.residual(a) <= flowTolerance {
+					continue
+				}
+				if d := potential[v] + arc.cost; !reached[arc.to] || d < potential[arc.to] {
+					potential[arc.to] = d
+					reached[arc.to] = true
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			return potential, nil
+		}
+	}
+	return nil, ErrNegativeCycle
+}
+
+// shortestPaths runs Dijkstra over the residual arcs with reduced costs, returning
+// the distances and, for every vertex reached, the arc it was reached through or -1.
+func (n *costNetwork) shortestPaths(source int, potential []float64) ([]float64, []int) {
+	dist := make([]float64, len(n.adj))
+	via := make([]int, len(n.adj))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		via[i] = -1
+	}
+	dist[source] = 0
+	queue := &indexQueue{{index: source}}
+	for queue.Len() > 0 {
+		it := heap.Pop(queue).(indexItem)
+		if it.priority > dist[it.index] {
+			continue
+		}
+		for _, a := range n.adj[it.index] {
+			arc := n.arcs[a]
+			if n.residual(a) <= flowTolerance {
+				continue
+			}
+			d := dist[it.index] + max(0, arc.cost+potential[it.index]-potential[arc.to])
+			if d < dist[arc.to] {
+				dist[arc.to] = d
+				via[arc.to] = a
+				heap.Push(queue, indexItem{index: arc.to, priority: d})
+			}
+		}
+	}
+	return dist, via
+}
+
+type indexItem struct {
+	index    int
+	priority float64
+}
+
+type indexQueue []indexItem
+
+func (q indexQueue) Len() int           { return len(q) }
+func (q indexQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q indexQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *indexQueue) Push(x any)        { *q = append(*q, x.(indexItem)) }
+
+func (q *indexQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
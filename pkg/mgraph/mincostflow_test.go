@@ -0,0 +1,79 @@
+package mgraph
+
+import (
+	"errors"
+	"testing"
+)
+
+// newAssignmentGraph links users to recommendation slots, each slot fitting one
+// user, at the cost of a mismatch.
+func newAssignmentGraph() Graph {
+	g := New(WithDirectedness(Directed), WithOrdering(InsertionOrder))
+	for _, id := range []VertexID{"u1", "u2", "u3", "s1", "s2", "s3"} {
+		_, _ = g.AddVertex(id)
+	}
+	for _, e := range []struct {
+		from, to VertexID
+		cost     float64
+	}{
+		{"u1", "s1", 4}, {"u1", "s2", 1}, {"u1", "s3", 3},
+		{"u2", "s1", 2}, {"u2", "s2", 0}, {"u2", "s3", 5},
+		{"u3", "s1", 3}, {"u3", "s2", 2}, {"u3", "s3", 2},
+	} {
+		_, _ = g.AddEdge(EdgeID(e.from+"-"+e.to), e.from, e.to, WithProperty("cost", e.cost))
+	}
+	return g
+}
+
+func byCost(e Edge) float64 {
+	c, _ := e.Property("cost")
+	return c.(float64)
+}
+
+func TestMinCostFlow(t *testing.T) {
+	g := newAssignmentGraph()
+	supply := map[VertexID]float64{"u1": 1, "u2": 1, "u3": 1, "s1": -1, "s2": -1, "s3": -1}
+	f, err := MinCostFlow(g, supply, nil, byCost)
+	if err != nil || f.Value != 3 || f.Cost != 5 {
+		t.Fatalf("MinCostFlow() expected 3 units at cost 5, got: %v at cost %v, %v", f.Value, f.Cost, err)
+	}
+	for _, id := range []EdgeID{"u1-s2", "u2-s1", "u3-s3"} {
+		if f.Edges[id] != 1 {
+			t.Fatalf("MinCostFlow() expected a unit through %s, got: %v", id, f.Edges)
+		}
+	}
+	if len(f.Edges) != g.Size() {
+		t.Fatalf("MinCostFlow() expected the flow of every edge, got: %v", f.Edges)
+	}
+}
+
+func TestMinCostFlow_Undirected(t *testing.T) {
+	g := New(WithDirectedness(Undirected))
+	for _, id := range []VertexID{"a", "b", "c"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("ba", "b", "a", WithProperty("cost", 1.0), WithProperty("capacity", 2.0))
+	_, _ = g.AddEdge("bc", "b", "c", WithProperty("cost", 1.0), WithProperty("capacity", 5.0))
+	_, _ = g.AddEdge("ac", "a", "c", WithProperty("cost", 3.0), WithProperty("capacity", 5.0))
+	f, err := MinCostFlow(g, map[VertexID]float64{"a": 4, "c": -4}, byCapacity, byCost)
+	if err != nil || f.Edges["ba"] != -2 || f.Edges["bc"] != 2 || f.Edges["ac"] != 2 || f.Cost != 10 {
+		t.Fatalf("MinCostFlow() expected 2 units through b and 2 through ac at cost 10, got: %v at cost %v, %v", f.Edges, f.Cost, err)
+	}
+}
+
+func TestMinCostFlow_Errors(t *testing.T) {
+	g := newAssignmentGraph()
+	if _, err := MinCostFlow(g, map[VertexID]float64{"u1": 1}, nil, byCost); !errors.Is(err, ErrUnbalancedSupply) {
+		t.Fatalf("MinCostFlow() expected error ErrUnbalancedSupply, got: %v", err)
+	}
+	if _, err := MinCostFlow(g, map[VertexID]float64{"u1": 2, "s1": -2}, nil, byCost); !errors.Is(err, ErrInfeasibleFlow) {
+		t.Fatalf("MinCostFlow() expected error ErrInfeasibleFlow, got: %v", err)
+	}
+	if _, err := MinCostFlow(g, map[VertexID]float64{"x": 0}, nil, byCost); !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("MinCostFlow() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	_, _ = g.AddEdge("s1-u1", "s1", "u1", WithProperty("cost", -5.0))
+	if _, err := MinCostFlow(g, map[VertexID]float64{"u1": 1, "s1": -1}, nil, byCost); !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("MinCostFlow() expected error ErrNegativeCycle, got: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package mgraph
+
+import "time"
+
+type Option func(p *properties)
+
+type Directedness int
+
+const (
+	Directed Directedness = iota
+	Undirected
+	// Mixed graphs hold directed edges unless they are added with UndirectedEdge.
+	Mixed
+)
+
+// WithOrdering makes every enumeration of the graph, its vertices and its edges
+// follow the given order instead of Go's random map order.
+func WithOrdering(ordering Ordering) Option {
+	return func(p *properties) {
+		p.ordering = ordering
+	}
+}
+
+func WithDirectedness(directedness Directedness) Option {
+	return func(p *properties) {
+		p.directedness = directedness
+	}
+}
+
+// WithSchema makes the graph enforce a schema from the start, as EnforceSchema.
+func WithSchema(schema *Schema) Option {
+	return func(p *properties) {
+		p.schema = schema
+	}
+}
+
+// WithHistory enables the history of the graph from the start, as EnableHistory.
+func WithHistory(limit int) Option {
+	return func(p *properties) {
+		p.history = max(limit, 0)
+	}
+}
+
+// WithVersioning makes the graph keep versions of itself that can be read while
+// it changes, see Version, At and Snapshot. Every mutation then also costs
+// O(log n) to update the versioned storage.
+func WithVersioning() Option {
+	return func(p *properties) {
+		p.versioning = true
+	}
+}
+
+func newProperties(opts []Option) properties {
+	p := defaultProperties()
+	for _, opt := range opts {
+		opt(&p)
+	}
+	return p
+}
+
+// ElementOption configures a vertex or an edge while it is added to a graph.
+type ElementOption func(o *elementOptions)
+
+type elementOptions struct {
+	label      string
+	properties map[string]any
+	validity   Interval
+	undirected bool
+}
+
+// WithLabel sets the kind of a vertex or an edge, such as "film" or "viewed".
+// Labeled elements are indexed by label; unlabeled ones are not.
+func WithLabel(label string) ElementOption {
+	return func(o *elementOptions) {
+		o.label = label
+	}
+}
+
+// WithProperty sets a property of a vertex or an edge as it is added.
+func WithProperty(property string, value any) ElementOption {
+	return func(o *elementOptions) {
+		if o.properties == nil {
+			o.properties = make(map[string]any)
+		}
+		o.properties[property] = value
+	}
+}
+
+// WithValidity sets the interval of time [from, to) during which a vertex or an
+// edge holds, see AsOf. A zero bound leaves that side of the interval open. It
+// can be changed later with SetValidity.
+func WithValidity(from, to time.Time) ElementOption {
+	return func(o *elementOptions) {
+		o.validity = Interval{From: from, To: to}
+	}
+}
+
+func UndirectedEdge() ElementOption {
+	return func(o *elementOptions) {
+		o.undirected = true
+	}
+}
+
+func newElementOptions(opts []ElementOption) elementOptions {
+	var o elementOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
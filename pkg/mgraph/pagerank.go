@@ -0,0 +1,149 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+var (
+	ErrNotConverged   = errors.New("ranks did not converge")
+	ErrInvalidSeeds   = errors.New("seeds must have non-negative weights adding up to more than zero")
+	ErrInvalidDamping = errors.New("damping must lie in [0, 1]")
+)
+
+type PageRankOptions struct {
+	// Damping is the probability of following an edge rather than restarting; nil
+	// means 0.85.
+	Damping *float64
+	// Tolerance bounds the sum of the rank changes of an iteration under which ranks
+	// have converged; zero means 1e-6.
+	Tolerance float64
+	// MaxIterations bounds the number of iterations; zero means 100.
+	MaxIterations int
+}
+
+func (o PageRankOptions) withDefaults() PageRankOptions {
+	if o.Damping == nil {
+		damping := 0.85
+		o.Damping = &damping
+	}
+	if o.Tolerance == 0 {
+		o.Tolerance = 1e-6
+	}
+	if o.MaxIterations == 0 {
+		o.MaxIterations = 100
+	}
+	return o
+}
+
+// PageRank ranks the vertices by the stationary probability of a random walk that
+// follows edges in proportion to their weight, so parallel edges add up, and that
+// restarts anywhere uniformly. Undirected edges are followed both ways, and the
+// walk restarts from vertices without outgoing weight. Ranks add up to 1. When they
+// do not converge within the maximum iterations, the last ones are returned along
+// with ErrNotConverged.
+func PageRank[V, E any](g TypedView[V, E], weigher Weigher[V, E], opts PageRankOptions) (map[VertexID]float64, error) {
+	seeds := make(map[VertexID]float64, g.Order())
+	for v := range g.AllVertices() {
+		seeds[v.Id()] = 1
+	}
+	return PersonalizedPageRank(g, seeds, weigher, opts)
+}
+
+// PersonalizedPageRank is PageRank with a walk restarting from the seed vertices
+// only, in proportion to their weight, such as a single user vertex.
+func PersonalizedPageRank[V, E any](g TypedView[V, E], seeds map[VertexID]float64, weigher Weigher[V, E], opts PageRankOptions) (map[VertexID]float64, error) {
+	opts = opts.withDefaults()
+	damping := *opts.Damping
+	if damping < 0 || damping > 1 || math.IsNaN(damping) {
+		return nil, fmt.Errorf("error while ranking: %v: %w", damping, ErrInvalidDamping)
+	}
+	index := make(map[VertexID]int, g.Order())
+	var ids []VertexID
+	for v := range g.AllVertices() {
+		index[v.Id()] = len(ids)
+		ids = append(ids, v.Id())
+	}
+	if len(ids) == 0 {
+		return map[VertexID]float64{}, nil
+	}
+	restart := make([]float64, len(ids))
+	var total float64
+	for id, w := range seeds {
+		i, ok := index[id]
+		if !ok {
+			return nil, fmt.Errorf("error while ranking: seed '%s': %w", id, ErrVertexDoesNotExists)
+		}
+		if w < 0 {
+			return nil, fmt.Errorf("error while ranking: seed '%s': %w", id, ErrInvalidSeeds)
+		}
+		restart[i] = w
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("error while ranking: %w", ErrInvalidSeeds)
+	}
+	for i := range restart {
+		restart[i] /= total
+	}
+	type link struct {
+		to     int
+		weight float64
+	}
+	links := make([][]link, len(ids))
+	out := make([]float64, len(ids))
+	for e := range g.AllEdges() {
+		from, okFrom := index[e.From()]
+		to, okTo := index[e.To()]
+		if !okFrom || !okTo {
+			continue
+		}
+		w := weigher.weight(e)
+		if w < 0 {
+			return nil, fmt.Errorf("error while ranking: edge '%s': %w", e.Id(), ErrNegativeWeight)
+		}
+		links[from] = append(links[from], link{to: to, weight: w})
+		out[from] += w
+		if !e.IsDirected() && from != to {
+			links[to] = append(links[to], link{to: from, weight: w})
+			out[to] += w
+		}
+	}
+	rank := slices.Clone(restart)
+	next := make([]float64, len(ids))
+	converged := false
+	for it := 0; it < opts.MaxIterations && !converged; it++ {
+		var dangling float64
+		for i := range next {
+			next[i] = 0
+			if out[i] == 0 {
+				dangling += rank[i]
+			}
+		}
+		for i := range links {
+			if out[i] == 0 {
+				continue
+			}
+			for _, l := range links[i] {
+				next[l.to] += rank[i] * l.weight / out[i]
+			}
+		}
+		var change float64
+		for i := range next {
+			next[i] = damping*(next[i]+dangling*restart[i]) + (1-damping)*restart[i]
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		converged = change < opts.Tolerance
+	}
+	ranks := make(map[VertexID]float64, len(ids))
+	for i, id := range ids {
+		ranks[id] = rank[i]
+	}
+	if !converged {
+		return ranks, fmt.Errorf("error while ranking: %w after %d iterations", ErrNotConverged, opts.MaxIterations)
+	}
+	return ranks, nil
+}
@@ -0,0 +1,97 @@
+package mgraph
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func newRankGraph() Graph {
+	g := New(WithDirectedness(Directed))
+	for _, id := range []VertexID{"user", "f1", "f2", "f3", "f4"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("user-f1", "user", "f1")
+	_, _ = g.AddEdge("f1-f2", "f1", "f2")
+	_, _ = g.AddEdge("f1-f2'", "f1", "f2")
+	_, _ = g.AddEdge("f1-f3", "f1", "f3")
+	_, _ = g.AddEdge("f2-f1", "f2", "f1")
+	_, _ = g.AddEdge("f3-f1", "f3", "f1")
+	_, _ = g.AddEdge("f4-f1", "f4", "f1", WithProperty("weight", 0.0))
+	return g
+}
+
+func sumRanks(ranks map[VertexID]float64) (sum float64) {
+	for _, r := range ranks {
+		sum += r
+	}
+	return
+}
+
+func TestPageRank(t *testing.T) {
+	g := newRankGraph()
+	ranks, err := PageRank(g, nil, PageRankOptions{})
+	if err != nil || math.Abs(sumRanks(ranks)-1) > 1e-6 {
+		t.Fatalf("PageRank() expected ranks adding up to 1, got: %v, %v", ranks, err)
+	}
+	if !(ranks["f1"] > ranks["f2"] && ranks["f2"] > ranks["f3"] && ranks["f3"] > ranks["user"]) {
+		t.Fatalf("PageRank() expected f1 > f2 > f3 > user, the parallel edges counting twice, got: %v", ranks)
+	}
+	weighted, _ := PageRank(g, func(e Edge) float64 {
+		if w, ok := e.Property("weight"); ok {
+			return w.(float64)
+		}
+		return 1
+	}, PageRankOptions{})
+	if weighted["f1"] >= ranks["f1"] {
+		t.Fatalf("PageRank() expected f4 to be dangling once its edge weighs 0, got: %v", weighted)
+	}
+	if _, err := PageRank(g, nil, PageRankOptions{MaxIterations: 2}); !errors.Is(err, ErrNotConverged) {
+		t.Fatalf("PageRank() expected error ErrNotConverged, got: %v", err)
+	}
+}
+
+func TestPageRank_Undirected(t *testing.T) {
+	g := New(WithDirectedness(Undirected))
+	for _, id := range []VertexID{"a", "b", "c"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("ab", "a", "b")
+	_, _ = g.AddEdge("bc", "b", "c")
+	ranks, _ := PageRank(g, nil, PageRankOptions{})
+	if math.Abs(ranks["a"]-ranks["c"]) > 1e-9 || ranks["b"] <= ranks["a"] {
+		t.Fatalf("PageRank() expected symmetric ranks around b, got: %v", ranks)
+	}
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	g := newRankGraph()
+	damping := 0.5
+	ranks, err := PersonalizedPageRank(g, map[VertexID]float64{"user": 1}, nil, PageRankOptions{Damping: &damping})
+	if err != nil || math.Abs(sumRanks(ranks)-1) > 1e-6 {
+		t.Fatalf("PersonalizedPageRank() expected ranks adding up to 1, got: %v, %v", ranks, err)
+	}
+	if ranks["f4"] != 0 || ranks["user"] < 0.5 {
+		t.Fatalf("PersonalizedPageRank() expected the walk to stay around user, got: %v", ranks)
+	}
+	if _, err := PersonalizedPageRank(g, map[VertexID]float64{"x": 1}, nil, PageRankOptions{}); !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("PersonalizedPageRank() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	if _, err := PersonalizedPageRank(g, map[VertexID]float64{}, nil, PageRankOptions{}); !errors.Is(err, ErrInvalidSeeds) {
+		t.Fatalf("PersonalizedPageRank() expected error ErrInvalidSeeds, got: %v", err)
+	}
+}
+
+func TestPageRank_Damping(t *testing.T) {
+	g := newRankGraph()
+	damping := 0.0
+	ranks, err := PersonalizedPageRank(g, map[VertexID]float64{"user": 1}, nil, PageRankOptions{Damping: &damping})
+	if err != nil || ranks["user"] != 1 {
+		t.Fatalf("PersonalizedPageRank() expected a zero damping to always restart, got: %v, %v", ranks, err)
+	}
+	for _, damping := range []float64{-0.1, 1.5, math.NaN()} {
+		if _, err := PageRank(g, nil, PageRankOptions{Damping: &damping}); !errors.Is(err, ErrInvalidDamping) {
+			t.Fatalf("PageRank() expected error ErrInvalidDamping for %v, got: %v", damping, err)
+		}
+	}
+}
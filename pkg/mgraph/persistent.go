@@ -0,0 +1,354 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrReadOnly          = errors.New("graph is read-only")
+	ErrEdgeDoesNotExists = errors.New("edge does not exists")
+)
+
+type Persistent = TypedPersistent[any, any]
+
+// TypedPersistent is an immutable graph. Its With and Without methods return a new
+// version of the graph that shares most of its structure with the original one,
+// which is left untouched, in O(log n) rather than the O(V+E) of Clone. Versions
+// can be shared between goroutines without locking.
+//
+// As a TypedView, it can be read and walked by every algorithm of the package.
+// Its vertices and edges keep the mutators of TypedVertex and TypedEdge, as does
+// the TypedGraph they belong to: those fail with ErrReadOnly, or panic with it when
+// they do not return an error. Vertex and edge payloads are shared as is between
+// versions. Clone returns a mutable copy.
+type TypedPersistent[V, E any] interface {
+	TypedView[V, E]
+	WithVertex(id VertexID, opts ...ElementOption) (TypedPersistent[V, E], error)
+	WithoutVertex(id VertexID) TypedPersistent[V, E]
+	WithVertexData(id VertexID, data V) (TypedPersistent[V, E], error)
+	WithEdge(id EdgeID, from, to VertexID, opts ...ElementOption) (TypedPersistent[V, E], error)
+	WithoutEdge(id EdgeID) TypedPersistent[V, E]
+	WithEdgeData(id EdgeID, data E) (TypedPersistent[V, E], error)
+}
+
+// NewPersistent returns an empty immutable graph. Of the options, it honors the
+// ordering, the directedness and the schema.
+func NewPersistent(opts ...Option) Persistent {
+	return NewTypedPersistent[any, any](opts...)
+}
+
+func NewTypedPersistent[V, E any](opts ...Option) TypedPersistent[V, E] {
+	return newPersistent[V, E](newProperties(opts))
+}
+
+func newPersistent[V, E any](properties properties) *persistent[V, E] {
+	p := &persistent[V, E]{properties: frozenProperties(properties)}
+	return p.sealed()
+}
+
+// frozenProperties drops the properties that only apply to mutable graphs.
+func frozenProperties(p properties) properties {
+	p.acyclicity = false
+	p.history = 0
+	p.versioning = false
+	return p
+}
+
+// Freeze returns an immutable copy of a graph, with its ordering, directedness,
+// schema and consistency. Vertices and edges keep their enumeration order.
+func Freeze[V, E any](g TypedView[V, E]) TypedPersistent[V, E] {
+	switch g := g.(type) {
+	case *persistent[V, E]:
+		return g
+	case readOnly[V, E]:
+		return g.persistent
+	case *tx[V, E]:
+		return g.graph.freeze()
+	case *graph[V, E]:
+		return g.freeze()
+	}
+	properties := defaultProperties()
+	properties.directedness = Mixed
+	properties.consistency = g.EnsuresConsistency()
+	properties.alwaysEnsuredConsistency = g.IsConsistent()
+	properties.schema = g.Schema()
+	return freeze(g, properties)
+}
+
+// freeze copies any graph through its public API.
+func freeze[V, E any](g TypedView[V, E], properties properties) *persistent[V, E] {
+	p := newPersistent[V, E](properties)
+	for v := range g.AllVertices() {
+		p.seq++
+		p.putVertex(&vertexRecord[V]{id: v.Id(), label: v.Label(), seq: p.seq, props: v.Properties(), validity: v.Validity(), data: v.Data()})
+	}
+	for e := range g.AllEdges() {
+		p.seq++
+		p.putEdge(&edgeRecord[E]{id: e.Id(), label: e.Label(), from: e.From(), to: e.To(), directed: e.IsDirected(), seq: p.seq, props: e.Properties(), validity: e.Validity(), data: e.Data()})
+	}
+	return p
+}
+
+func (g *graph[V, E]) freeze() *persistent[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	p := newPersistent[V, E](g.properties)
+	p.seq = g.seq
+	for v := range g.vertices.values() {
+		p.putVertex(&vertexRecord[V]{id: v.id, label: v.label, seq: v.seq, props: copyProperties(v.props), validity: v.validity, data: v.data})
+	}
+	for e := range g.edges.values() {
+		p.putEdge(&edgeRecord[E]{id: e.id, label: e.label, from: e.from, to: e.to, directed: e.directed, seq: e.seq, props: copyProperties(e.props), validity: e.validity, data: e.data})
+	}
+	return p
+}
+
+// persistent is a version of an immutable graph. Versions are copied by value and
+// their tries updated, never their records, which are replaced.
+type persistent[V, E any] struct {
+	properties properties
+	vertices   hamt[VertexID, *vertexRecord[V]]
+	edges      hamt[EdgeID, *edgeRecord[E]]
+	edgesFrom  hamt[VertexID, hamt[EdgeID, struct{}]]
+	edgesTo    hamt[VertexID, hamt[EdgeID, struct{}]]
+
+	vertexLabels hamt[string, hamt[VertexID, struct{}]]
+	edgeLabels   hamt[string, hamt[EdgeID, struct{}]]
+
+	seq     uint64
+	version uint64
+	order   *versionOrder[V, E]
+}
+
+// versionOrder caches the vertices and edges of a version in enumeration order,
+// sorted on first use. Versions never change once built, so the cache stays valid;
+// copies of a version get their own through sealed.
+type versionOrder[V, E any] struct {
+	vertices func() []*vertexRecord[V]
+	edges    func() []*edgeRecord[E]
+}
+
+// sealed gives a version built by copying another one a fresh order cache.
+func (p *persistent[V, E]) sealed() *persistent[V, E] {
+	p.order = &versionOrder[V, E]{
+		vertices: sync.OnceValue(func() []*vertexRecord[V] {
+			return sortElements(slices.Collect(values(p.vertices.all())), p.properties.ordering)
+		}),
+		edges: sync.OnceValue(func() []*edgeRecord[E] {
+			return sortElements(slices.Collect(values(p.edges.all())), p.properties.ordering)
+		}),
+	}
+	return p
+}
+
+type vertexRecord[V any] struct {
+	id       VertexID
+	label    string
+	seq      uint64
+	props    map[string]any
+	validity Interval
+	data     V
+}
+
+type edgeRecord[E any] struct {
+	id       EdgeID
+	label    string
+	from     VertexID
+	to       VertexID
+	directed bool
+	seq      uint64
+	props    map[string]any
+	validity Interval
+	data     E
+}
+
+func (p *persistent[V, E]) WithVertex(id VertexID, opts ...ElementOption) (TypedPersistent[V, E], error) {
+	o := newElementOptions(opts)
+	if _, ok := p.vertices.get(id); ok {
+		return nil, fmt.Errorf("error while adding vertex '%s': %w", id, ErrVertexAlreadyAdded)
+	}
+	if schema := p.properties.schema; schema != nil {
+		if violations := schema.checkVertex(id, o.label, o.properties); len(violations) > 0 {
+			return nil, fmt.Errorf("error while adding vertex '%s': %w", id, &violations[0])
+		}
+	}
+	next := *p
+	next.seq++
+	next.version++
+	next.putVertex(&vertexRecord[V]{id: id, label: o.label, seq: next.seq, props: o.properties, validity: o.validity})
+	return next.sealed(), nil
+}
+
+func (p *persistent[V, E]) putVertex(v *vertexRecord[V]) {
+	p.vertices = p.vertices.set(v.id, v)
+	if v.label != "" {
+		p.vertexLabels = addMember(p.vertexLabels, v.label, v.id)
+	}
+}
+
+// WithoutVertex returns the graph without the vertex and, when the graph ensures
+// consistency, without its edges. It returns the graph itself when the vertex
+// does not exist.
+func (p *persistent[V, E]) WithoutVertex(id VertexID) TypedPersistent[V, E] {
+	v, ok := p.vertices.get(id)
+	if !ok {
+		return p
+	}
+	next := *p
+	next.version++
+	if p.properties.consistency {
+		for _, adjacency := range [2]hamt[VertexID, hamt[EdgeID, struct{}]]{p.edgesFrom, p.edgesTo} {
+			edges, _ := adjacency.get(id)
+			for eid := range edges.keys() {
+				next.dropEdge(eid)
+			}
+		}
+	}
+	next.dropVertex(v)
+	return next.sealed()
+}
+
+func (p *persistent[V, E]) dropVertex(v *vertexRecord[V]) {
+	p.vertices = p.vertices.delete(v.id)
+	if v.label != "" {
+		p.vertexLabels = removeMember(p.vertexLabels, v.label, v.id)
+	}
+}
+
+func (p *persistent[V, E]) WithVertexData(id VertexID, data V) (TypedPersistent[V, E], error) {
+	v, ok := p.vertices.get(id)
+	if !ok {
+		return nil, fmt.Errorf("error while storing data of vertex '%s': %w", id, ErrVertexDoesNotExists)
+	}
+	updated := *v
+	updated.data = data
+	next := *p
+	next.version++
+	next.vertices = next.vertices.set(id, &updated)
+	return next.sealed(), nil
+}
+
+func (p *persistent[V, E]) WithEdge(id EdgeID, from, to VertexID, opts ...ElementOption) (TypedPersistent[V, E], error) {
+	o := newElementOptions(opts)
+	if _, ok := p.edges.get(id); ok {
+		return nil, fmt.Errorf("error while adding edge '%s': %w", id, ErrEdgeAlreadyAdded)
+	}
+	directed := p.properties.directedness != Undirected && !o.undirected
+	if !directed && p.properties.directedness == Directed {
+		return nil, fmt.Errorf("error while adding edge '%s': %w", id, ErrUndirectedEdge)
+	}
+	if p.properties.consistency {
+		if _, ok := p.vertices.get(from); !ok {
+			return nil, fmt.Errorf("error while adding edge '%s': %w", id, fmt.Errorf("vertex '%s' does not exists: %w", from, ErrVertexDoesNotExists))
+		}
+		if _, ok := p.vertices.get(to); !ok {
+			return nil, fmt.Errorf("error while adding edge '%s': %w", id, fmt.Errorf("vertex '%s' does not exists: %w", to, ErrVertexDoesNotExists))
+		}
+	}
+	if schema := p.properties.schema; schema != nil {
+		if violations := schema.checkEdge(id, o.label, p.vertexLabel(from), p.vertexLabel(to), directed, o.properties); len(violations) > 0 {
+			return nil, fmt.Errorf("error while adding edge '%s': %w", id, &violations[0])
+		}
+	}
+	next := *p
+	next.seq++
+	next.version++
+	next.putEdge(&edgeRecord[E]{id: id, label: o.label, from: from, to: to, directed: directed, seq: next.seq, props: o.properties, validity: o.validity})
+	return next.sealed(), nil
+}
+
+// putEdge adds an edge with its adjacency; undirected edges are both outgoing and
+// incoming edges of each of their endpoints, as in mutable graphs.
+func (p *persistent[V, E]) putEdge(e *edgeRecord[E]) {
+	p.edges = p.edges.set(e.id, e)
+	p.edgesFrom = addMember(p.edgesFrom, e.from, e.id)
+	p.edgesTo = addMember(p.edgesTo, e.to, e.id)
+	if !e.directed && e.from != e.to {
+		p.edgesFrom = addMember(p.edgesFrom, e.to, e.id)
+		p.edgesTo = addMember(p.edgesTo, e.from, e.id)
+	}
+	if e.label != "" {
+		p.edgeLabels = addMember(p.edgeLabels, e.label, e.id)
+	}
+}
+
+// WithoutEdge returns the graph without the edge, or the graph itself when the
+// edge does not exist.
+func (p *persistent[V, E]) WithoutEdge(id EdgeID) TypedPersistent[V, E] {
+	if _, ok := p.edges.get(id); !ok {
+		return p
+	}
+	next := *p
+	next.version++
+	next.dropEdge(id)
+	return next.sealed()
+}
+
+func (p *persistent[V, E]) dropEdge(id EdgeID) {
+	e, ok := p.edges.get(id)
+	if !ok {
+		return
+	}
+	for _, v := range [2]VertexID{e.from, e.to} {
+		p.edgesFrom = removeMember(p.edgesFrom, v, id)
+		p.edgesTo = removeMember(p.edgesTo, v, id)
+	}
+	if e.label != "" {
+		p.edgeLabels = removeMember(p.edgeLabels, e.label, id)
+	}
+	p.edges = p.edges.delete(id)
+}
+
+func (p *persistent[V, E]) WithEdgeData(id EdgeID, data E) (TypedPersistent[V, E], error) {
+	e, ok := p.edges.get(id)
+	if !ok {
+		return nil, fmt.Errorf("error while storing data of edge '%s': %w", id, ErrEdgeDoesNotExists)
+	}
+	updated := *e
+	updated.data = data
+	next := *p
+	next.version++
+	next.edges = next.edges.set(id, &updated)
+	return next.sealed(), nil
+}
+
+func (p *persistent[V, E]) vertexLabel(id VertexID) *string {
+	if v, ok := p.vertices.get(id); ok {
+		return &v.label
+	}
+	return nil
+}
+
+func (p *persistent[V, E]) Clone() TypedGraph[V, E] {
+	return p.CloneWith(DataCloner[V, E]{})
+}
+
+// CloneWith returns a mutable copy of the graph.
+func (p *persistent[V, E]) CloneWith(cloner DataCloner[V, E]) TypedGraph[V, E] {
+	g := newGraph[V, E](noLock{}, p.properties)
+	g.seq = p.seq
+	for v := range p.allVertices() {
+		newV := newVertex(v.id, v.label, v.seq, g)
+		newV.props = copyProperties(v.props)
+		newV.validity = v.validity
+		newV.data = v.data
+		if cloner.Vertex != nil {
+			newV.data = cloner.Vertex(v.data)
+		}
+		g.addVertex(newV)
+	}
+	for e := range p.allEdges() {
+		newE := newEdge(e.id, e.from, e.to, e.label, e.directed, e.seq, g)
+		newE.props = copyProperties(e.props)
+		newE.validity = e.validity
+		newE.data = e.data
+		if cloner.Edge != nil {
+			newE.data = cloner.Edge(e.data)
+		}
+		g.addEdge(newE)
+	}
+	return g
+}
@@ -0,0 +1,202 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestPersistent_Versions(t *testing.T) {
+	g0 := NewPersistent(WithOrdering(InsertionOrder))
+	g1, _ := g0.WithVertex("a", WithLabel("node"))
+	g2, _ := g1.WithVertex("b")
+	g3, err := g2.WithEdge("ab", "a", "b", WithProperty("weight", 2))
+	if err != nil {
+		t.Fatalf("WithEdge() expected no error, got: %v", err)
+	}
+	g4, _ := g3.WithVertexData("a", "data")
+	g5 := g4.WithoutVertex("b")
+	for i, expected := range []struct{ order, size int }{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 1}, {1, 0}} {
+		g := []Persistent{g0, g1, g2, g3, g4, g5}[i]
+		if g.Order() != expected.order || g.Size() != expected.size {
+			t.Fatalf("version %d expected %d vertices and %d edges, got: %d and %d", i, expected.order, expected.size, g.Order(), g.Size())
+		}
+	}
+	if data := g3.Vertex("a").Data(); data != nil {
+		t.Fatalf("WithVertexData() expected the previous version to be unchanged, got: %v", data)
+	}
+	if data := g4.Vertex("a").Data(); data != "data" {
+		t.Fatalf("WithVertexData() expected data, got: %v", data)
+	}
+	if edges := g4.Vertex("a").Outgoing(); !slices.Equal(edgeIDs(edges), []EdgeID{"ab"}) {
+		t.Fatalf("Outgoing() expected [ab], got: %v", edgeIDs(edges))
+	}
+	if edges := g5.Vertex("a").Outgoing(); len(edges) != 0 {
+		t.Fatalf("WithoutVertex() expected the edges of the vertex to be removed, got: %v", edgeIDs(edges))
+	}
+	if vertices := g5.VerticesByLabel("node"); !slices.Equal(vertexIDs(vertices), []VertexID{"a"}) {
+		t.Fatalf("VerticesByLabel() expected [a], got: %v", vertexIDs(vertices))
+	}
+}
+
+func TestPersistent_Vertices_CachesOrder(t *testing.T) {
+	g0, _ := NewPersistent(WithOrdering(SortedByID)).WithVertex("b")
+	g1, _ := g0.WithVertex("a")
+	if ids := vertexIDs(g1.Vertices()); !slices.Equal(ids, []VertexID{"a", "b"}) {
+		t.Fatalf("Vertices() expected [a b], got: %v", ids)
+	}
+	g2, _ := g1.WithVertex("c")
+	if ids := vertexIDs(g2.Vertices()); !slices.Equal(ids, []VertexID{"a", "b", "c"}) {
+		t.Fatalf("Vertices() expected [a b c] on the next version, got: %v", ids)
+	}
+	if ids := vertexIDs(g1.Vertices()); !slices.Equal(ids, []VertexID{"a", "b"}) {
+		t.Fatalf("Vertices() expected [a b] to be kept, got: %v", ids)
+	}
+	g := New(WithOrdering(SortedByID), WithVersioning())
+	_, _ = g.AddVertex("b")
+	_ = vertexIDs(mustAt(t, g, g.Version()).Vertices())
+	_, _ = g.AddVertex("a")
+	if ids := vertexIDs(mustAt(t, g, g.Version()).Vertices()); !slices.Equal(ids, []VertexID{"a", "b"}) {
+		t.Fatalf("Vertices() expected [a b] on the new head, got: %v", ids)
+	}
+}
+
+func TestPersistent_Errors(t *testing.T) {
+	g, _ := NewPersistent(WithDirectedness(Directed)).WithVertex("a")
+	if _, err := g.WithVertex("a"); !errors.Is(err, ErrVertexAlreadyAdded) {
+		t.Fatalf("WithVertex() expected error ErrVertexAlreadyAdded, got: %v", err)
+	}
+	if _, err := g.WithEdge("ab", "a", "b"); !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("WithEdge() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	if _, err := g.WithEdge("aa", "a", "a", UndirectedEdge()); !errors.Is(err, ErrUndirectedEdge) {
+		t.Fatalf("WithEdge() expected error ErrUndirectedEdge, got: %v", err)
+	}
+	if _, err := g.WithEdgeData("ab", nil); !errors.Is(err, ErrEdgeDoesNotExists) {
+		t.Fatalf("WithEdgeData() expected error ErrEdgeDoesNotExists, got: %v", err)
+	}
+	if g.WithoutEdge("ab") != g {
+		t.Fatalf("WithoutEdge() expected the same version for a missing edge")
+	}
+}
+
+func TestPersistent_ReadOnly(t *testing.T) {
+	g, _ := NewPersistent().WithVertex("a")
+	if _, ok := g.(Graph); ok {
+		t.Fatalf("NewPersistent() expected a read-only view, got a graph")
+	}
+	if _, err := g.Vertex("a").Graph().AddVertex("b"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("AddVertex() expected error ErrReadOnly, got: %v", err)
+	}
+	if err := g.Vertex("a").SetProperty("name", "a"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("SetProperty() expected error ErrReadOnly, got: %v", err)
+	}
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrReadOnly) {
+			t.Fatalf("RemoveVertex() expected to panic with ErrReadOnly, got: %v", err)
+		}
+	}()
+	g.Vertex("a").Graph().RemoveVertex("a")
+}
+
+func TestFreeze(t *testing.T) {
+	g := newTxGraph()
+	p := Freeze(g)
+	if frozen, expected := dumpGraph(p), dumpGraph(g); frozen != expected {
+		t.Fatalf("Freeze() expected\n%s, got:\n%s", expected, frozen)
+	}
+	g.RemoveVertex("a")
+	if p.Vertex("a") == nil || p.Size() != 5 {
+		t.Fatalf("Freeze() expected the copy to be unchanged by the graph")
+	}
+	if thawed, expected := dumpGraph(p.Clone()), dumpGraph(p); thawed != expected {
+		t.Fatalf("Clone() expected\n%s, got:\n%s", expected, thawed)
+	}
+}
+
+func TestPersistent_Algorithms(t *testing.T) {
+	p := Freeze(newWeightedGraph())
+	path, cost, err := Dijkstra(p, "node1", "node4", byRating)
+	if err != nil || !slices.Equal(edgeIDs(path), []EdgeID{"edge2", "edge3", "edge5"}) || cost != 6 {
+		t.Fatalf("Dijkstra() expected [edge2 edge3 edge5] of cost 6, got: %v of cost %v, %v", edgeIDs(path), cost, err)
+	}
+	if _, err := TopologicalSort(p); err != nil {
+		t.Fatalf("TopologicalSort() expected no error, got: %v", err)
+	}
+}
+
+func TestPersistent_Concurrent(t *testing.T) {
+	g := NewPersistent()
+	for i := 0; i < 100; i++ {
+		g, _ = g.WithVertex(VertexID(fmt.Sprint(i)))
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version := g
+			for j := 0; j < 100; j++ {
+				version, _ = version.WithEdge(EdgeID(fmt.Sprint(i, "-", j)), VertexID(fmt.Sprint(j)), VertexID(fmt.Sprint((j+i)%100)))
+			}
+			if version.Size() != 100 || g.Size() != 0 {
+				t.Errorf("WithEdge() expected 100 edges in the new version and none in the shared one")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func newBenchmarkGraph(n int) Graph {
+	g := New()
+	for i := 0; i < n; i++ {
+		_, _ = g.AddVertex(VertexID(fmt.Sprint(i)))
+	}
+	for i := 0; i < n; i++ {
+		_, _ = g.AddEdge(EdgeID(fmt.Sprint(i)), VertexID(fmt.Sprint(i)), VertexID(fmt.Sprint((i*7+1)%n)))
+	}
+	return g
+}
+
+// The snapshot-per-request pattern: take a copy of the graph to change it while
+// readers keep the previous one.
+func BenchmarkGraph_CloneAndAddEdge(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		g := newBenchmarkGraph(n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c := g.Clone()
+				_, _ = c.AddEdge("new", "0", "1")
+			}
+		})
+	}
+}
+
+func BenchmarkPersistent_WithEdge(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		p := Freeze(newBenchmarkGraph(n))
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = p.WithEdge("new", "0", "1")
+			}
+		})
+	}
+}
+
+func BenchmarkGraph_Vertex(b *testing.B) {
+	g := newBenchmarkGraph(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = g.Vertex(VertexID(fmt.Sprint(i % 10000)))
+	}
+}
+
+func BenchmarkPersistent_Vertex(b *testing.B) {
+	p := Freeze(newBenchmarkGraph(10000))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = p.Vertex(VertexID(fmt.Sprint(i % 10000)))
+	}
+}
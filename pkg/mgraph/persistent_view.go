@@ -0,0 +1,620 @@
+package mgraph
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// persistentVertex and persistentEdge are read-only views of the records of a
+// version, bound to it.
+type persistentVertex[V, E any] struct {
+	*vertexRecord[V]
+	graph *persistent[V, E]
+}
+
+type persistentEdge[V, E any] struct {
+	*edgeRecord[E]
+	graph *persistent[V, E]
+}
+
+func (p *persistent[V, E]) Vertex(id VertexID) TypedVertex[V, E] {
+	if v, ok := p.vertices.get(id); ok {
+		return &persistentVertex[V, E]{vertexRecord: v, graph: p}
+	}
+	return nil
+}
+
+func (p *persistent[V, E]) Vertices() []TypedVertex[V, E] {
+	return slices.AppendSeq(make([]TypedVertex[V, E], 0, p.vertices.len()), p.AllVertices())
+}
+
+func (p *persistent[V, E]) ForEachVertex(each func(v TypedVertex[V, E]) bool) {
+	for v := range p.AllVertices() {
+		if !each(v) {
+			return
+		}
+	}
+}
+
+func (p *persistent[V, E]) AllVertices() iter.Seq[TypedVertex[V, E]] {
+	return values(p.VertexEntries())
+}
+
+func (p *persistent[V, E]) VertexEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return p.vertexEntries(p.allVertices())
+}
+
+func (p *persistent[V, E]) Edge(id EdgeID) TypedEdge[V, E] {
+	if e, ok := p.edges.get(id); ok {
+		return &persistentEdge[V, E]{edgeRecord: e, graph: p}
+	}
+	return nil
+}
+
+func (p *persistent[V, E]) Edges() []TypedEdge[V, E] {
+	return slices.AppendSeq(make([]TypedEdge[V, E], 0, p.edges.len()), p.AllEdges())
+}
+
+func (p *persistent[V, E]) ForEachEdge(each func(e TypedEdge[V, E]) bool) {
+	for e := range p.AllEdges() {
+		if !each(e) {
+			return
+		}
+	}
+}
+
+func (p *persistent[V, E]) AllEdges() iter.Seq[TypedEdge[V, E]] {
+	return values(p.EdgeEntries())
+}
+
+func (p *persistent[V, E]) EdgeEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return p.edgeEntries(p.allEdges())
+}
+
+func (p *persistent[V, E]) EdgesBetween(from, to VertexID) (edges []TypedEdge[V, E]) {
+	for _, e := range p.edgeEntries(p.adjacent(p.edgesFrom, from)) {
+		if opposite(e, from) == to {
+			edges = append(edges, e)
+		}
+	}
+	return
+}
+
+func (p *persistent[V, E]) VerticesByLabel(label string) []TypedVertex[V, E] {
+	ids, _ := p.vertexLabels.get(label)
+	vertices := make([]*vertexRecord[V], 0, ids.len())
+	for id := range ids.keys() {
+		v, _ := p.vertices.get(id)
+		vertices = append(vertices, v)
+	}
+	sorted := p.vertexEntries(slices.Values(sortElements(vertices, p.properties.ordering)))
+	return slices.AppendSeq(make([]TypedVertex[V, E], 0, len(vertices)), values(sorted))
+}
+
+func (p *persistent[V, E]) EdgesByLabel(label string) []TypedEdge[V, E] {
+	ids, _ := p.edgeLabels.get(label)
+	edges := make([]*edgeRecord[E], 0, ids.len())
+	for id := range ids.keys() {
+		e, _ := p.edges.get(id)
+		edges = append(edges, e)
+	}
+	sorted := p.edgeEntries(slices.Values(sortElements(edges, p.properties.ordering)))
+	return slices.AppendSeq(make([]TypedEdge[V, E], 0, len(edges)), values(sorted))
+}
+
+// EdgesBetweenByLabel filters the edges between the vertices, taking time in
+// their number whatever the label.
+func (p *persistent[V, E]) EdgesBetweenByLabel(from, to VertexID, label string) []TypedEdge[V, E] {
+	return slices.DeleteFunc(p.EdgesBetween(from, to), func(e TypedEdge[V, E]) bool {
+		return e.Label() != label
+	})
+}
+
+func (p *persistent[V, E]) OrderByLabel(label string) int {
+	ids, _ := p.vertexLabels.get(label)
+	return ids.len()
+}
+
+func (p *persistent[V, E]) SizeByLabel(label string) int {
+	ids, _ := p.edgeLabels.get(label)
+	return ids.len()
+}
+
+func (p *persistent[V, E]) VertexLabels() []string {
+	return slices.Sorted(p.vertexLabels.keys())
+}
+
+func (p *persistent[V, E]) EdgeLabels() []string {
+	return slices.Sorted(p.edgeLabels.keys())
+}
+
+// VerticesByProperty scans the vertices, as persistent graphs have no index.
+func (p *persistent[V, E]) VerticesByProperty(property string, value any) []TypedVertex[V, E] {
+	return vertexViews(lookup(nil, p.vertexViews(), property, value))
+}
+
+func (p *persistent[V, E]) EdgesByProperty(property string, value any) []TypedEdge[V, E] {
+	return edgeViews(lookup(nil, p.edgeViews(), property, value))
+}
+
+func (p *persistent[V, E]) VerticesByPropertyRange(property string, from, to any) ([]TypedVertex[V, E], error) {
+	found, err := lookupRange(nil, p.vertexViews(), property, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking up vertex property '%s': %w", property, err)
+	}
+	return vertexViews(found), nil
+}
+
+func (p *persistent[V, E]) EdgesByPropertyRange(property string, from, to any) ([]TypedEdge[V, E], error) {
+	found, err := lookupRange(nil, p.edgeViews(), property, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking up edge property '%s': %w", property, err)
+	}
+	return edgeViews(found), nil
+}
+
+func (p *persistent[V, E]) Order() int {
+	return p.vertices.len()
+}
+
+func (p *persistent[V, E]) Size() int {
+	return p.edges.len()
+}
+
+func (p *persistent[V, E]) Degree() (degree int) {
+	for _, v := range p.vertices.all() {
+		degree = max(degree, p.degree(v.id))
+	}
+	return
+}
+
+func (p *persistent[V, E]) EnsuresConsistency() bool {
+	return p.properties.consistency
+}
+
+func (p *persistent[V, E]) EnsuresAcyclicity() bool {
+	return false
+}
+
+func (p *persistent[V, E]) IsConsistent() bool {
+	if p.properties.alwaysEnsuredConsistency {
+		return true
+	}
+	for _, e := range p.edges.all() {
+		_, fromOk := p.vertices.get(e.from)
+		_, toOk := p.vertices.get(e.to)
+		if !fromOk || !toOk {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *persistent[V, E]) Schema() *Schema {
+	return p.properties.schema
+}
+
+// Subscribe never calls the function, as the graph never changes.
+func (p *persistent[V, E]) Subscribe(func(Event)) (cancel func()) {
+	return func() {}
+}
+
+// readOnly is a persistent graph as the TypedGraph that the vertices and edges of
+// its views belong to: its mutators fail with ErrReadOnly, or panic with it when
+// they do not return an error.
+type readOnly[V, E any] struct {
+	*persistent[V, E]
+}
+
+func (r readOnly[V, E]) AddVertex(id VertexID, _ ...ElementOption) (TypedVertex[V, E], error) {
+	return nil, fmt.Errorf("error while adding vertex '%s': %w", id, ErrReadOnly)
+}
+
+func (r readOnly[V, E]) RemoveVertex(id VertexID) {
+	panic(fmt.Errorf("error while removing vertex '%s': %w", id, ErrReadOnly))
+}
+
+func (r readOnly[V, E]) AddEdge(id EdgeID, _, _ VertexID, _ ...ElementOption) (TypedEdge[V, E], error) {
+	return nil, fmt.Errorf("error while adding edge '%s': %w", id, ErrReadOnly)
+}
+
+func (r readOnly[V, E]) RemoveEdge(id EdgeID) {
+	panic(fmt.Errorf("error while removing edge '%s': %w", id, ErrReadOnly))
+}
+
+func (r readOnly[V, E]) CreateVertexIndex(property string, _ IndexKind) error {
+	return fmt.Errorf("error while creating index on vertex property '%s': %w", property, ErrReadOnly)
+}
+
+func (r readOnly[V, E]) CreateEdgeIndex(property string, _ IndexKind) error {
+	return fmt.Errorf("error while creating index on edge property '%s': %w", property, ErrReadOnly)
+}
+
+func (r readOnly[V, E]) DropVertexIndex(property string) {
+	panic(fmt.Errorf("error while dropping index on vertex property '%s': %w", property, ErrReadOnly))
+}
+
+func (r readOnly[V, E]) DropEdgeIndex(property string) {
+	panic(fmt.Errorf("error while dropping index on edge property '%s': %w", property, ErrReadOnly))
+}
+
+func (r readOnly[V, E]) EnsureConsistency(bool) {
+	panic(fmt.Errorf("error while ensuring consistency: %w", ErrReadOnly))
+}
+
+func (r readOnly[V, E]) EnsureAcyclicity(bool) error {
+	return fmt.Errorf("error while ensuring acyclicity: %w", ErrReadOnly)
+}
+
+func (r readOnly[V, E]) EnforceSchema(*Schema) {
+	panic(fmt.Errorf("error while enforcing schema: %w", ErrReadOnly))
+}
+
+func (r readOnly[V, E]) Begin() TypedTx[V, E] {
+	panic(fmt.Errorf("error while beginning transaction: %w", ErrReadOnly))
+}
+
+func (r readOnly[V, E]) EnableHistory(int) {
+	panic(fmt.Errorf("error while enabling history: %w", ErrReadOnly))
+}
+
+func (r readOnly[V, E]) Undo() error {
+	return fmt.Errorf("error while undoing: %w", ErrReadOnly)
+}
+
+func (r readOnly[V, E]) Redo() error {
+	return fmt.Errorf("error while redoing: %w", ErrReadOnly)
+}
+
+func (r readOnly[V, E]) Checkpoint(name string) error {
+	return fmt.Errorf("error while creating checkpoint '%s': %w", name, ErrReadOnly)
+}
+
+func (r readOnly[V, E]) RestoreCheckpoint(name string) error {
+	return fmt.Errorf("error while restoring checkpoint '%s': %w", name, ErrReadOnly)
+}
+
+func (p *persistent[V, E]) allVertices() iter.Seq[*vertexRecord[V]] {
+	if p.properties.ordering == Unordered {
+		return values(p.vertices.all())
+	}
+	return slices.Values(p.order.vertices())
+}
+
+func (p *persistent[V, E]) allEdges() iter.Seq[*edgeRecord[E]] {
+	if p.properties.ordering == Unordered {
+		return values(p.edges.all())
+	}
+	return slices.Values(p.order.edges())
+}
+
+// adjacent enumerates the edges leaving or entering a vertex in graph order.
+func (p *persistent[V, E]) adjacent(adjacency hamt[VertexID, hamt[EdgeID, struct{}]], id VertexID) iter.Seq[*edgeRecord[E]] {
+	ids, _ := adjacency.get(id)
+	return inOrder(func(yield func(*edgeRecord[E]) bool) {
+		for id := range ids.keys() {
+			if e, ok := p.edges.get(id); ok && !yield(e) {
+				return
+			}
+		}
+	}, p.properties.ordering)
+}
+
+// degree counts loops twice and every other incident edge once.
+func (p *persistent[V, E]) degree(id VertexID) int {
+	out, _ := p.edgesFrom.get(id)
+	in, _ := p.edgesTo.get(id)
+	degree := out.len() + in.len()
+	if p.properties.directedness != Directed {
+		for e := range p.adjacent(p.edgesFrom, id) {
+			if !e.directed && e.from != e.to {
+				degree--
+			}
+		}
+	}
+	return degree
+}
+
+func (p *persistent[V, E]) vertexEntries(vertices iter.Seq[*vertexRecord[V]]) iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return func(yield func(VertexID, TypedVertex[V, E]) bool) {
+		for v := range vertices {
+			if !yield(v.id, &persistentVertex[V, E]{vertexRecord: v, graph: p}) {
+				return
+			}
+		}
+	}
+}
+
+func (p *persistent[V, E]) edgeEntries(edges iter.Seq[*edgeRecord[E]]) iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return func(yield func(EdgeID, TypedEdge[V, E]) bool) {
+		for e := range edges {
+			if !yield(e.id, &persistentEdge[V, E]{edgeRecord: e, graph: p}) {
+				return
+			}
+		}
+	}
+}
+
+func (p *persistent[V, E]) vertexViews() iter.Seq[*persistentVertex[V, E]] {
+	return func(yield func(*persistentVertex[V, E]) bool) {
+		for v := range p.allVertices() {
+			if !yield(&persistentVertex[V, E]{vertexRecord: v, graph: p}) {
+				return
+			}
+		}
+	}
+}
+
+func (p *persistent[V, E]) edgeViews() iter.Seq[*persistentEdge[V, E]] {
+	return func(yield func(*persistentEdge[V, E]) bool) {
+		for e := range p.allEdges() {
+			if !yield(&persistentEdge[V, E]{edgeRecord: e, graph: p}) {
+				return
+			}
+		}
+	}
+}
+
+func vertexViews[V, E any](found []*persistentVertex[V, E]) []TypedVertex[V, E] {
+	vertices := make([]TypedVertex[V, E], 0, len(found))
+	for _, v := range found {
+		vertices = append(vertices, v)
+	}
+	return vertices
+}
+
+func edgeViews[V, E any](found []*persistentEdge[V, E]) []TypedEdge[V, E] {
+	edges := make([]TypedEdge[V, E], 0, len(found))
+	for _, e := range found {
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// sequenced is a vertex or an edge numbered in the order it was added.
+type sequenced[K cmp.Ordered] interface {
+	Id() K
+	serial() uint64
+}
+
+// inOrder enumerates items in the given order; unordered items are enumerated as
+// they come, ordered ones are sorted first.
+func inOrder[K cmp.Ordered, T sequenced[K]](items iter.Seq[T], ordering Ordering) iter.Seq[T] {
+	if ordering == Unordered {
+		return items
+	}
+	return func(yield func(T) bool) {
+		for _, item := range sortElements(slices.Collect(items), ordering) {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func sortElements[K cmp.Ordered, T sequenced[K]](items []T, ordering Ordering) []T {
+	switch ordering {
+	case InsertionOrder:
+		slices.SortFunc(items, func(a, b T) int { return cmp.Compare(a.serial(), b.serial()) })
+	case SortedByID:
+		slices.SortFunc(items, func(a, b T) int { return cmp.Compare(a.Id(), b.Id()) })
+	}
+	return items
+}
+
+func (v *vertexRecord[V]) Id() VertexID {
+	return v.id
+}
+
+func (v *vertexRecord[V]) Label() string {
+	return v.label
+}
+
+func (v *vertexRecord[V]) Data() V {
+	return v.data
+}
+
+func (v *vertexRecord[V]) Property(property string) (any, bool) {
+	value, ok := v.props[property]
+	return value, ok
+}
+
+func (v *vertexRecord[V]) Properties() map[string]any {
+	return copyProperties(v.props)
+}
+
+func (v *vertexRecord[V]) Validity() Interval {
+	return v.validity
+}
+
+func (v *vertexRecord[V]) propertyMap() map[string]any {
+	return v.props
+}
+
+func (v *vertexRecord[V]) serial() uint64 {
+	return v.seq
+}
+
+func (v *persistentVertex[V, E]) SetProperty(property string, _ any) error {
+	return fmt.Errorf("error while setting property of vertex '%s': %w", v.id, ErrReadOnly)
+}
+
+func (v *persistentVertex[V, E]) RemoveProperty(property string) error {
+	return fmt.Errorf("error while removing property of vertex '%s': %w", v.id, ErrReadOnly)
+}
+
+func (v *persistentVertex[V, E]) SetValidity(time.Time, time.Time) error {
+	return fmt.Errorf("error while setting validity of vertex '%s': %w", v.id, ErrReadOnly)
+}
+
+func (v *persistentVertex[V, E]) StoreData(V) {
+	panic(fmt.Errorf("error while storing data of vertex '%s': %w", v.id, ErrReadOnly))
+}
+
+func (v *persistentVertex[V, E]) Incoming() []TypedEdge[V, E] {
+	return slices.Collect(v.In())
+}
+
+func (v *persistentVertex[V, E]) Outgoing() []TypedEdge[V, E] {
+	return slices.Collect(v.Out())
+}
+
+func (v *persistentVertex[V, E]) Edges() []TypedEdge[V, E] {
+	records := slices.Collect(v.graph.adjacent(v.graph.edgesTo, v.id))
+	for e := range v.graph.adjacent(v.graph.edgesFrom, v.id) {
+		if e.directed && !e.IsLoop() {
+			records = append(records, e)
+		}
+	}
+	sorted := v.graph.edgeEntries(slices.Values(sortElements(records, v.graph.properties.ordering)))
+	return slices.AppendSeq(make([]TypedEdge[V, E], 0, len(records)), values(sorted))
+}
+
+// IncomingByLabel and OutgoingByLabel filter the adjacency of the vertex, taking
+// time in its degree whatever the label.
+func (v *persistentVertex[V, E]) IncomingByLabel(label string) []TypedEdge[V, E] {
+	return slices.DeleteFunc(v.Incoming(), func(e TypedEdge[V, E]) bool {
+		return e.Label() != label
+	})
+}
+
+func (v *persistentVertex[V, E]) OutgoingByLabel(label string) []TypedEdge[V, E] {
+	return slices.DeleteFunc(v.Outgoing(), func(e TypedEdge[V, E]) bool {
+		return e.Label() != label
+	})
+}
+
+func (v *persistentVertex[V, E]) In() iter.Seq[TypedEdge[V, E]] {
+	return values(v.InEntries())
+}
+
+func (v *persistentVertex[V, E]) InEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.graph.edgeEntries(v.graph.adjacent(v.graph.edgesTo, v.id))
+}
+
+func (v *persistentVertex[V, E]) Out() iter.Seq[TypedEdge[V, E]] {
+	return values(v.OutEntries())
+}
+
+func (v *persistentVertex[V, E]) OutEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.graph.edgeEntries(v.graph.adjacent(v.graph.edgesFrom, v.id))
+}
+
+func (v *persistentVertex[V, E]) Neighbors() iter.Seq[TypedVertex[V, E]] {
+	return values(v.NeighborEntries())
+}
+
+func (v *persistentVertex[V, E]) NeighborEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return neighborEntries[V, E](v.graph, v.id, v.OutEntries(), v.InEntries())
+}
+
+func (v *persistentVertex[V, E]) BelongsTo(edge TypedEdge[V, E]) bool {
+	return v.id == edge.To() || v.id == edge.From()
+}
+
+func (v *persistentVertex[V, E]) Degree() int {
+	return v.graph.degree(v.id)
+}
+
+func (v *persistentVertex[V, E]) Graph() TypedGraph[V, E] {
+	return readOnly[V, E]{v.graph}
+}
+
+func (e *edgeRecord[E]) Id() EdgeID {
+	return e.id
+}
+
+func (e *edgeRecord[E]) Label() string {
+	return e.label
+}
+
+func (e *edgeRecord[E]) From() VertexID {
+	return e.from
+}
+
+func (e *edgeRecord[E]) To() VertexID {
+	return e.to
+}
+
+func (e *edgeRecord[E]) Data() E {
+	return e.data
+}
+
+func (e *edgeRecord[E]) IsLoop() bool {
+	return e.from == e.to
+}
+
+func (e *edgeRecord[E]) IsDirected() bool {
+	return e.directed
+}
+
+func (e *edgeRecord[E]) Property(property string) (any, bool) {
+	value, ok := e.props[property]
+	return value, ok
+}
+
+func (e *edgeRecord[E]) Properties() map[string]any {
+	return copyProperties(e.props)
+}
+
+func (e *edgeRecord[E]) Validity() Interval {
+	return e.validity
+}
+
+func (e *edgeRecord[E]) propertyMap() map[string]any {
+	return e.props
+}
+
+func (e *edgeRecord[E]) serial() uint64 {
+	return e.seq
+}
+
+func (e *persistentEdge[V, E]) SetProperty(property string, _ any) error {
+	return fmt.Errorf("error while setting property of edge '%s': %w", e.id, ErrReadOnly)
+}
+
+func (e *persistentEdge[V, E]) RemoveProperty(property string) error {
+	return fmt.Errorf("error while removing property of edge '%s': %w", e.id, ErrReadOnly)
+}
+
+func (e *persistentEdge[V, E]) SetValidity(time.Time, time.Time) error {
+	return fmt.Errorf("error while setting validity of edge '%s': %w", e.id, ErrReadOnly)
+}
+
+func (e *persistentEdge[V, E]) StoreData(E) {
+	panic(fmt.Errorf("error while storing data of edge '%s': %w", e.id, ErrReadOnly))
+}
+
+func (e *persistentEdge[V, E]) Tail() TypedVertex[V, E] {
+	return e.graph.Vertex(e.from)
+}
+
+func (e *persistentEdge[V, E]) Head() TypedVertex[V, E] {
+	return e.graph.Vertex(e.to)
+}
+
+func (e *persistentEdge[V, E]) Endpoints() [2]TypedVertex[V, E] {
+	return [2]TypedVertex[V, E]{e.Tail(), e.Head()}
+}
+
+func (e *persistentEdge[V, E]) IsIncident(vertex TypedVertex[V, E]) bool {
+	return e.from == vertex.Id() || e.to == vertex.Id()
+}
+
+func (e *persistentEdge[V, E]) IsInverted(edge TypedEdge[V, E]) bool {
+	return e.from == edge.To() && e.to == edge.From()
+}
+
+func (e *persistentEdge[V, E]) IsParallel(edge TypedEdge[V, E]) bool {
+	if !e.directed || !edge.IsDirected() {
+		return e.from == edge.From() && e.to == edge.To() || e.from == edge.To() && e.to == edge.From()
+	}
+	return e.from == edge.From() && e.to == edge.To()
+}
+
+func (e *persistentEdge[V, E]) Graph() TypedGraph[V, E] {
+	return readOnly[V, E]{e.graph}
+}
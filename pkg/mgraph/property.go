@@ -0,0 +1,321 @@
+package mgraph
+
+import (
+	"fmt"
+	"maps"
+)
+
+func (g *graph[V, E]) CreateVertexIndex(property string, kind IndexKind) error {
+	return g.createVertexIndexBy(nil, property, kind)
+}
+
+func (g *graph[V, E]) createVertexIndexBy(t *tx[V, E], property string, kind IndexKind) error {
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while creating index on vertex property '%s': %w", property, err)
+	}
+	defer unlock()
+	g.seal()
+	if _, ok := g.vertexIndexes[property]; ok {
+		return fmt.Errorf("error while creating index on vertex property '%s': %w", property, ErrIndexAlreadyCreated)
+	}
+	x := newIndex[VertexID, *vertex[V, E]](kind, g.properties.ordering)
+	if err := x.load(property, g.vertices.values()); err != nil {
+		return fmt.Errorf("error while creating index on vertex property '%s': %w", property, err)
+	}
+	g.vertexIndexes[property] = x
+	g.record(func() { delete(g.vertexIndexes, property) })
+	return nil
+}
+
+func (g *graph[V, E]) CreateEdgeIndex(property string, kind IndexKind) error {
+	return g.createEdgeIndexBy(nil, property, kind)
+}
+
+func (g *graph[V, E]) createEdgeIndexBy(t *tx[V, E], property string, kind IndexKind) error {
+	unlock, err := g.lock(t)
+	if err != nil {
+		return fmt.Errorf("error while creating index on edge property '%s': %w", property, err)
+	}
+	defer unlock()
+	g.seal()
+	if _, ok := g.edgeIndexes[property]; ok {
+		return fmt.Errorf("error while creating index on edge property '%s': %w", property, ErrIndexAlreadyCreated)
+	}
+	x := newIndex[EdgeID, *edge[V, E]](kind, g.properties.ordering)
+	if err := x.load(property, g.edges.values()); err != nil {
+		return fmt.Errorf("error while creating index on edge property '%s': %w", property, err)
+	}
+	g.edgeIndexes[property] = x
+	g.record(func() { delete(g.edgeIndexes, property) })
+	return nil
+}
+
+func (g *graph[V, E]) DropVertexIndex(property string) {
+	g.dropVertexIndexBy(nil, property)
+}
+
+func (g *graph[V, E]) dropVertexIndexBy(t *tx[V, E], property string) {
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while dropping index on vertex property '%s': %w", property, err))
+	}
+	defer unlock()
+	g.seal()
+	if x, ok := g.vertexIndexes[property]; ok {
+		delete(g.vertexIndexes, property)
+		g.record(func() { g.vertexIndexes[property] = x })
+	}
+}
+
+func (g *graph[V, E]) DropEdgeIndex(property string) {
+	g.dropEdgeIndexBy(nil, property)
+}
+
+func (g *graph[V, E]) dropEdgeIndexBy(t *tx[V, E], property string) {
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while dropping index on edge property '%s': %w", property, err))
+	}
+	defer unlock()
+	g.seal()
+	if x, ok := g.edgeIndexes[property]; ok {
+		delete(g.edgeIndexes, property)
+		g.record(func() { g.edgeIndexes[property] = x })
+	}
+}
+
+// VerticesByProperty returns the vertices whose property equals the given value.
+// It uses the index of the property when there is one and scans otherwise.
+func (g *graph[V, E]) VerticesByProperty(property string, value any) []TypedVertex[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return vertexSlice(lookup(g.vertexIndexes, g.vertices.values(), property, value))
+}
+
+func (g *graph[V, E]) EdgesByProperty(property string, value any) []TypedEdge[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return edgeSlice(lookup(g.edgeIndexes, g.edges.values(), property, value))
+}
+
+// VerticesByPropertyRange returns the vertices whose property lies in [from, to),
+// sorted by value, then by ID. A nil bound leaves that side of the range open.
+// It uses the index of the property when it is ordered and scans otherwise.
+func (g *graph[V, E]) VerticesByPropertyRange(property string, from, to any) ([]TypedVertex[V, E], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	found, err := lookupRange(g.vertexIndexes, g.vertices.values(), property, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking up vertex property '%s': %w", property, err)
+	}
+	return vertexSlice(found), nil
+}
+
+func (g *graph[V, E]) EdgesByPropertyRange(property string, from, to any) ([]TypedEdge[V, E], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	found, err := lookupRange(g.edgeIndexes, g.edges.values(), property, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("error while looking up edge property '%s': %w", property, err)
+	}
+	return edgeSlice(found), nil
+}
+
+func vertexSlice[V, E any](found []*vertex[V, E]) []TypedVertex[V, E] {
+	vertices := make([]TypedVertex[V, E], 0, len(found))
+	for _, v := range found {
+		vertices = append(vertices, v)
+	}
+	return vertices
+}
+
+func edgeSlice[V, E any](found []*edge[V, E]) []TypedEdge[V, E] {
+	edges := make([]TypedEdge[V, E], 0, len(found))
+	for _, e := range found {
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// SetProperty fails when the schema of the graph or the index of the property
+// rejects the value, leaving the vertex unchanged.
+func (v *vertex[V, E]) SetProperty(property string, value any) error {
+	return v.setPropertyBy(nil, property, value)
+}
+
+func (v *vertex[V, E]) setPropertyBy(t *tx[V, E], property string, value any) error {
+	defer v.hub.publish()
+	unlock, err := lock(v.mu, v.txMu, t, func() { v.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while setting property of vertex '%s': %w", v.id, err)
+	}
+	defer unlock()
+	v.graph.seal()
+	if err := v.checkProperty(property, value, false); err != nil {
+		return fmt.Errorf("error while setting property of vertex '%s': %w", v.id, err)
+	}
+	if err := v.setProperty(property, value, false); err != nil {
+		return fmt.Errorf("error while setting property of vertex '%s': %w", v.id, err)
+	}
+	return nil
+}
+
+func (v *vertex[V, E]) RemoveProperty(property string) error {
+	return v.removePropertyBy(nil, property)
+}
+
+func (v *vertex[V, E]) removePropertyBy(t *tx[V, E], property string) error {
+	defer v.hub.publish()
+	unlock, err := lock(v.mu, v.txMu, t, func() { v.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while removing property of vertex '%s': %w", v.id, err)
+	}
+	defer unlock()
+	v.graph.seal()
+	if err := v.checkProperty(property, nil, true); err != nil {
+		return fmt.Errorf("error while removing property of vertex '%s': %w", v.id, err)
+	}
+	_ = v.setProperty(property, nil, true)
+	return nil
+}
+
+// setProperty sets or removes a property, keeping the indexes of the graph in sync.
+func (v *vertex[V, E]) setProperty(property string, value any, removed bool) error {
+	old, had := v.props[property]
+	if v.graph != nil {
+		if err := v.graph.vertexIndexes.update(v, property, value, removed); err != nil {
+			return err
+		}
+		v.graph.record(func() { _ = v.setProperty(property, old, !had) })
+		v.graph.hub.emit(Event{Kind: VertexPropertyChanged, Vertex: v.id, Property: property})
+	}
+	if removed {
+		delete(v.props, property)
+	} else {
+		if v.props == nil {
+			v.props = make(map[string]any)
+		}
+		v.props[property] = value
+	}
+	if v.graph != nil {
+		v.graph.mirrorVertex(v)
+	}
+	return nil
+}
+
+func (v *vertex[V, E]) Property(property string) (any, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	value, ok := v.props[property]
+	return value, ok
+}
+
+// Properties returns a copy of the property map of the vertex.
+func (v *vertex[V, E]) Properties() map[string]any {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return copyProperties(v.props)
+}
+
+func (v *vertex[V, E]) propertyMap() map[string]any {
+	return v.props
+}
+
+func (v *vertex[V, E]) serial() uint64 {
+	return v.seq
+}
+
+// SetProperty fails when the schema of the graph or the index of the property
+// rejects the value, leaving the edge unchanged.
+func (e *edge[V, E]) SetProperty(property string, value any) error {
+	return e.setPropertyBy(nil, property, value)
+}
+
+func (e *edge[V, E]) setPropertyBy(t *tx[V, E], property string, value any) error {
+	defer e.hub.publish()
+	unlock, err := lock(e.mu, e.txMu, t, func() { e.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while setting property of edge '%s': %w", e.id, err)
+	}
+	defer unlock()
+	e.graph.seal()
+	if err := e.checkProperty(property, value, false); err != nil {
+		return fmt.Errorf("error while setting property of edge '%s': %w", e.id, err)
+	}
+	if err := e.setProperty(property, value, false); err != nil {
+		return fmt.Errorf("error while setting property of edge '%s': %w", e.id, err)
+	}
+	return nil
+}
+
+func (e *edge[V, E]) RemoveProperty(property string) error {
+	return e.removePropertyBy(nil, property)
+}
+
+func (e *edge[V, E]) removePropertyBy(t *tx[V, E], property string) error {
+	defer e.hub.publish()
+	unlock, err := lock(e.mu, e.txMu, t, func() { e.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while removing property of edge '%s': %w", e.id, err)
+	}
+	defer unlock()
+	e.graph.seal()
+	if err := e.checkProperty(property, nil, true); err != nil {
+		return fmt.Errorf("error while removing property of edge '%s': %w", e.id, err)
+	}
+	_ = e.setProperty(property, nil, true)
+	return nil
+}
+
+// setProperty sets or removes a property, keeping the indexes of the graph in sync.
+func (e *edge[V, E]) setProperty(property string, value any, removed bool) error {
+	old, had := e.props[property]
+	if e.graph != nil {
+		if err := e.graph.edgeIndexes.update(e, property, value, removed); err != nil {
+			return err
+		}
+		e.graph.record(func() { _ = e.setProperty(property, old, !had) })
+		e.graph.hub.emit(Event{Kind: EdgePropertyChanged, Edge: e.id, From: e.from, To: e.to, Property: property})
+	}
+	if removed {
+		delete(e.props, property)
+	} else {
+		if e.props == nil {
+			e.props = make(map[string]any)
+		}
+		e.props[property] = value
+	}
+	if e.graph != nil {
+		e.graph.mirrorEdge(e)
+	}
+	return nil
+}
+
+func (e *edge[V, E]) Property(property string) (any, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	value, ok := e.props[property]
+	return value, ok
+}
+
+// Properties returns a copy of the property map of the edge.
+func (e *edge[V, E]) Properties() map[string]any {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return copyProperties(e.props)
+}
+
+func (e *edge[V, E]) propertyMap() map[string]any {
+	return e.props
+}
+
+func (e *edge[V, E]) serial() uint64 {
+	return e.seq
+}
+
+func copyProperties(props map[string]any) map[string]any {
+	clone := make(map[string]any, len(props))
+	maps.Copy(clone, props)
+	return clone
+}
@@ -0,0 +1,175 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func newRatingGraph() Graph {
+	g := New(WithOrdering(InsertionOrder))
+	_, _ = g.AddVertex("alice", WithLabel("user"), WithProperty("username", "alice42"))
+	_, _ = g.AddVertex("bob", WithLabel("user"), WithProperty("username", "bobby"))
+	_, _ = g.AddVertex("alien", WithLabel("film"))
+	_, _ = g.AddVertex("heat", WithLabel("film"))
+	_, _ = g.AddEdge("e1", "alice", "alien", WithLabel("viewed"), WithProperty("rating", 5))
+	_, _ = g.AddEdge("e2", "alice", "heat", WithLabel("viewed"), WithProperty("rating", 3))
+	_, _ = g.AddEdge("e3", "bob", "alien", WithLabel("viewed"), WithProperty("rating", 4.5))
+	_, _ = g.AddEdge("e4", "bob", "heat", WithLabel("viewed"), WithProperty("rating", 4))
+	return g
+}
+
+func TestVertex_Properties(t *testing.T) {
+	g := newRatingGraph()
+	v := g.Vertex("alice")
+	if value, ok := v.Property("username"); !ok || value != "alice42" {
+		t.Fatalf("Property() expected alice42, got: %v", value)
+	}
+	if err := v.SetProperty("age", 31); err != nil {
+		t.Fatalf("SetProperty() expected no error, got: %v", err)
+	}
+	props := v.Properties()
+	if len(props) != 2 || props["age"] != 31 {
+		t.Fatalf("Properties() expected username and age, got: %v", props)
+	}
+	props["age"] = 32
+	if value, _ := v.Property("age"); value != 31 {
+		t.Fatalf("Properties() expected a copy, got property changed to: %v", value)
+	}
+	v.RemoveProperty("age")
+	if _, ok := v.Property("age"); ok {
+		t.Fatalf("RemoveProperty() expected property to be removed")
+	}
+}
+
+func TestGraph_VerticesByProperty(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		g := newRatingGraph()
+		if indexed {
+			if err := g.CreateVertexIndex("username", HashIndex); err != nil {
+				t.Fatalf("CreateVertexIndex() expected no error, got: %v", err)
+			}
+		}
+		if ids := vertexIDs(g.VerticesByProperty("username", "bobby")); !slices.Equal(ids, []VertexID{"bob"}) {
+			t.Fatalf("VerticesByProperty() expected [bob] with index %v, got: %v", indexed, ids)
+		}
+		_ = g.Vertex("bob").SetProperty("username", "bob")
+		if ids := vertexIDs(g.VerticesByProperty("username", "bobby")); len(ids) != 0 {
+			t.Fatalf("VerticesByProperty() expected no vertices with index %v, got: %v", indexed, ids)
+		}
+		if ids := vertexIDs(g.VerticesByProperty("username", "bob")); !slices.Equal(ids, []VertexID{"bob"}) {
+			t.Fatalf("VerticesByProperty() expected [bob] with index %v, got: %v", indexed, ids)
+		}
+	}
+}
+
+func TestGraph_EdgesByPropertyRange(t *testing.T) {
+	for _, kind := range []IndexKind{-1, HashIndex, OrderedIndex} {
+		g := newRatingGraph()
+		if kind >= 0 {
+			_ = g.CreateEdgeIndex("rating", kind)
+		}
+		edges, err := g.EdgesByPropertyRange("rating", 4, nil)
+		if err != nil {
+			t.Fatalf("EdgesByPropertyRange() expected no error, got: %v", err)
+		}
+		if ids := edgeIDs(edges); !slices.Equal(ids, []EdgeID{"e4", "e3", "e1"}) {
+			t.Fatalf("EdgesByPropertyRange() expected [e4 e3 e1] with index %d, got: %v", kind, ids)
+		}
+		edges, _ = g.EdgesByPropertyRange("rating", nil, 4.5)
+		if ids := edgeIDs(edges); !slices.Equal(ids, []EdgeID{"e2", "e4"}) {
+			t.Fatalf("EdgesByPropertyRange() expected [e2 e4] with index %d, got: %v", kind, ids)
+		}
+		if ids := edgeIDs(g.EdgesByProperty("rating", 4)); !slices.Equal(ids, []EdgeID{"e4"}) {
+			t.Fatalf("EdgesByProperty() expected [e4] with index %d, got: %v", kind, ids)
+		}
+	}
+}
+
+func TestGraph_EdgesByPropertyRange_ErrorUnorderedValue(t *testing.T) {
+	g := newRatingGraph()
+	if _, err := g.EdgesByPropertyRange("rating", []int{4}, nil); !errors.Is(err, ErrUnorderedValue) {
+		t.Fatalf("EdgesByPropertyRange() expected error ErrUnorderedValue, got: %v", err)
+	}
+}
+
+func TestGraph_Index_RejectsInvalidValues(t *testing.T) {
+	g := newRatingGraph()
+	_ = g.CreateEdgeIndex("rating", OrderedIndex)
+	_ = g.CreateVertexIndex("tags", HashIndex)
+	if err := g.Edge("e1").SetProperty("rating", struct{}{}); !errors.Is(err, ErrUnorderedValue) {
+		t.Fatalf("SetProperty() expected error ErrUnorderedValue, got: %v", err)
+	}
+	if value, _ := g.Edge("e1").Property("rating"); value != 5 {
+		t.Fatalf("SetProperty() expected rating to be left unchanged, got: %v", value)
+	}
+	if _, err := g.AddVertex("carol", WithProperty("tags", []string{"new"})); !errors.Is(err, ErrUncomparableValue) {
+		t.Fatalf("AddVertex() expected error ErrUncomparableValue, got: %v", err)
+	}
+	if g.Vertex("carol") != nil {
+		t.Fatalf("AddVertex() expected vertex not to be added")
+	}
+	if _, err := g.AddVertex("carol", WithProperty("tags", [1]any{[]string{"new"}})); !errors.Is(err, ErrUncomparableValue) {
+		t.Fatalf("AddVertex() expected error ErrUncomparableValue for an array holding a slice, got: %v", err)
+	}
+	if err := g.CreateEdgeIndex("rating", HashIndex); !errors.Is(err, ErrIndexAlreadyCreated) {
+		t.Fatalf("CreateEdgeIndex() expected error ErrIndexAlreadyCreated, got: %v", err)
+	}
+	_ = g.Vertex("alien").SetProperty("genres", []string{"sf"})
+	if err := g.CreateVertexIndex("genres", HashIndex); !errors.Is(err, ErrUncomparableValue) {
+		t.Fatalf("CreateVertexIndex() expected error ErrUncomparableValue, got: %v", err)
+	}
+}
+
+func TestGraph_RemoveVertex_UpdatesPropertyIndexes(t *testing.T) {
+	g := newRatingGraph()
+	_ = g.CreateVertexIndex("username", HashIndex)
+	_ = g.CreateEdgeIndex("rating", OrderedIndex)
+	g.RemoveVertex("alice")
+	if vertices := g.VerticesByProperty("username", "alice42"); len(vertices) != 0 {
+		t.Fatalf("VerticesByProperty() expected no vertices, got: %v", vertexIDs(vertices))
+	}
+	edges, _ := g.EdgesByPropertyRange("rating", nil, nil)
+	if ids := edgeIDs(edges); !slices.Equal(ids, []EdgeID{"e4", "e3"}) {
+		t.Fatalf("EdgesByPropertyRange() expected [e4 e3], got: %v", ids)
+	}
+	g.RemoveEdge("e3")
+	if edges := g.EdgesByProperty("rating", 4.5); len(edges) != 0 {
+		t.Fatalf("EdgesByProperty() expected no edges, got: %v", edgeIDs(edges))
+	}
+}
+
+func TestGraph_Clone_KeepsPropertiesAndIndexes(t *testing.T) {
+	g := newRatingGraph()
+	_ = g.CreateEdgeIndex("rating", OrderedIndex)
+	clone := g.Clone()
+	_ = g.Edge("e1").SetProperty("rating", 1)
+	if value, _ := clone.Edge("e1").Property("rating"); value != 5 {
+		t.Fatalf("Property() expected 5 on clone, got: %v", value)
+	}
+	if err := clone.CreateEdgeIndex("rating", OrderedIndex); !errors.Is(err, ErrIndexAlreadyCreated) {
+		t.Fatalf("CreateEdgeIndex() expected error ErrIndexAlreadyCreated on clone, got: %v", err)
+	}
+	if ids := edgeIDs(clone.EdgesByProperty("rating", 5)); !slices.Equal(ids, []EdgeID{"e1"}) {
+		t.Fatalf("EdgesByProperty() expected [e1] on clone, got: %v", ids)
+	}
+}
+
+func TestGraph_OrderedIndex_OutOfOrder(t *testing.T) {
+	g := New()
+	_ = g.CreateVertexIndex("rank", OrderedIndex)
+	for i, rank := range []int{5, 3, 9, 1, 7, 3} {
+		_, _ = g.AddVertex(VertexID("v"+strconv.Itoa(i)), WithProperty("rank", rank))
+	}
+	_ = g.Vertex("v2").SetProperty("rank", 0)
+	g.RemoveVertex("v3")
+	_ = g.Vertex("v4").SetProperty("rank", 7)
+	found, _ := g.VerticesByPropertyRange("rank", nil, nil)
+	if ids := vertexIDs(found); !slices.Equal(ids, []VertexID{"v2", "v1", "v5", "v0", "v4"}) {
+		t.Fatalf("VerticesByPropertyRange() expected [v2 v1 v5 v0 v4], got: %v", ids)
+	}
+	if ids := vertexIDs(g.VerticesByProperty("rank", 3)); !slices.Equal(ids, []VertexID{"v1", "v5"}) {
+		t.Fatalf("VerticesByProperty() expected [v1 v5], got: %v", ids)
+	}
+}
@@ -0,0 +1,231 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUndeclaredLabel     = errors.New("label is not declared by the schema")
+	ErrInvalidEndpoints    = errors.New("edge label does not connect these vertex labels")
+	ErrMissingProperty     = errors.New("required property is missing")
+	ErrInvalidPropertyType = errors.New("property has an invalid type")
+)
+
+// Schema declares the vertex and edge labels of a graph by label. Once enforced
+// by a graph, it must not be modified anymore.
+type Schema struct {
+	Vertices map[string]VertexSchema
+	Edges    map[string]EdgeSchema
+}
+
+// VertexSchema lists the properties every vertex of a label requires.
+type VertexSchema struct {
+	Properties map[string]PropertyType
+}
+
+// EdgeSchema restricts the labels of the tail and the head of the edges of a
+// label, such as user -> chapter|film; an empty list allows any label.
+// Undirected edges may connect the labels either way.
+type EdgeSchema struct {
+	From       []string
+	To         []string
+	Properties map[string]PropertyType
+}
+
+type PropertyType int
+
+const (
+	AnyProperty PropertyType = iota
+	BoolProperty
+	// IntProperty accepts every signed and unsigned integer type.
+	IntProperty
+	// FloatProperty accepts every number, integers included.
+	FloatProperty
+	StringProperty
+)
+
+func (t PropertyType) accepts(value any) bool {
+	v := reflect.ValueOf(value)
+	switch t {
+	case BoolProperty:
+		return v.Kind() == reflect.Bool
+	case IntProperty:
+		return v.CanInt() || v.CanUint()
+	case FloatProperty:
+		return v.CanInt() || v.CanUint() || v.CanFloat()
+	case StringProperty:
+		return v.Kind() == reflect.String
+	}
+	return true
+}
+
+// Violation is a breach of the schema by a vertex or an edge. AddVertex, AddEdge
+// and property changes return it wrapped when they are rejected.
+type Violation struct {
+	Vertex   VertexID
+	Edge     EdgeID
+	Label    string
+	Property string
+	Err      error
+}
+
+func (v *Violation) Error() string {
+	var b strings.Builder
+	if v.Edge != "" {
+		fmt.Fprintf(&b, "edge '%s'", v.Edge)
+	} else {
+		fmt.Fprintf(&b, "vertex '%s'", v.Vertex)
+	}
+	fmt.Fprintf(&b, " labeled '%s'", v.Label)
+	if v.Property != "" {
+		fmt.Fprintf(&b, ", property '%s'", v.Property)
+	}
+	fmt.Fprintf(&b, ": %v", v.Err)
+	return b.String()
+}
+
+func (v *Violation) Unwrap() error {
+	return v.Err
+}
+
+func (s *Schema) checkVertex(id VertexID, label string, props map[string]any) []Violation {
+	vs, ok := s.Vertices[label]
+	if !ok {
+		return []Violation{{Vertex: id, Label: label, Err: ErrUndeclaredLabel}}
+	}
+	violations := checkProperties(vs.Properties, props)
+	for i := range violations {
+		violations[i].Vertex, violations[i].Label = id, label
+	}
+	return violations
+}
+
+// checkEdge leaves the endpoints unchecked when an endpoint label is unknown,
+// that is when the vertex is missing from an inconsistent graph.
+func (s *Schema) checkEdge(id EdgeID, label string, from, to *string, directed bool, props map[string]any) []Violation {
+	es, ok := s.Edges[label]
+	if !ok {
+		return []Violation{{Edge: id, Label: label, Err: ErrUndeclaredLabel}}
+	}
+	var violations []Violation
+	if from != nil && to != nil && !es.connects(*from, *to) && (directed || !es.connects(*to, *from)) {
+		violations = append(violations, Violation{Edge: id, Label: label, Err: fmt.Errorf("%w: '%s' -> '%s'", ErrInvalidEndpoints, *from, *to)})
+	}
+	for _, violation := range checkProperties(es.Properties, props) {
+		violation.Edge, violation.Label = id, label
+		violations = append(violations, violation)
+	}
+	return violations
+}
+
+func (es EdgeSchema) connects(from, to string) bool {
+	return (len(es.From) == 0 || slices.Contains(es.From, from)) && (len(es.To) == 0 || slices.Contains(es.To, to))
+}
+
+func checkProperties(required map[string]PropertyType, props map[string]any) (violations []Violation) {
+	for _, property := range slices.Sorted(maps.Keys(required)) {
+		value, ok := props[property]
+		if !ok {
+			violations = append(violations, Violation{Property: property, Err: ErrMissingProperty})
+		} else if !required[property].accepts(value) {
+			violations = append(violations, Violation{Property: property, Err: ErrInvalidPropertyType})
+		}
+	}
+	return
+}
+
+// checkProperty checks a property about to be set, or removed when removed is
+// true, against the declared properties of a label.
+func checkProperty(required map[string]PropertyType, property string, value any, removed bool) error {
+	t, ok := required[property]
+	switch {
+	case !ok:
+		return nil
+	case removed:
+		return ErrMissingProperty
+	case !t.accepts(value):
+		return ErrInvalidPropertyType
+	}
+	return nil
+}
+
+func (g *graph[V, E]) EnforceSchema(schema *Schema) {
+	g.enforceSchemaBy(nil, schema)
+}
+
+func (g *graph[V, E]) enforceSchemaBy(t *tx[V, E], schema *Schema) {
+	unlock, err := g.lock(t)
+	if err != nil {
+		panic(fmt.Errorf("error while enforcing schema: %w", err))
+	}
+	defer unlock()
+	g.seal()
+	old := g.properties.schema
+	g.record(func() { g.properties.schema = old })
+	g.properties.schema = schema
+}
+
+func (g *graph[V, E]) Schema() *Schema {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.properties.schema
+}
+
+func (g *graph[V, E]) vertexLabel(id VertexID) *string {
+	if v, ok := g.vertices.get(id); ok {
+		return &v.label
+	}
+	return nil
+}
+
+func (v *vertex[V, E]) checkProperty(property string, value any, removed bool) error {
+	if v.graph == nil || v.graph.properties.schema == nil {
+		return nil
+	}
+	if err := checkProperty(v.graph.properties.schema.Vertices[v.label].Properties, property, value, removed); err != nil {
+		return &Violation{Vertex: v.id, Label: v.label, Property: property, Err: err}
+	}
+	return nil
+}
+
+func (e *edge[V, E]) checkProperty(property string, value any, removed bool) error {
+	if e.graph == nil || e.graph.properties.schema == nil {
+		return nil
+	}
+	if err := checkProperty(e.graph.properties.schema.Edges[e.label].Properties, property, value, removed); err != nil {
+		return &Violation{Edge: e.id, Label: e.label, Property: property, Err: err}
+	}
+	return nil
+}
+
+// Validate audits a graph against the schema it enforces, reporting every
+// violation in enumeration order. Elements added before the schema was enforced
+// are not checked otherwise. A graph without schema has no violations.
+func Validate[V, E any](g TypedView[V, E]) []Violation {
+	schema := g.Schema()
+	if schema == nil {
+		return nil
+	}
+	var violations []Violation
+	labels := make(map[VertexID]string, g.Order())
+	for v := range g.AllVertices() {
+		labels[v.Id()] = v.Label()
+		violations = append(violations, schema.checkVertex(v.Id(), v.Label(), v.Properties())...)
+	}
+	for e := range g.AllEdges() {
+		var from, to *string
+		if label, ok := labels[e.From()]; ok {
+			from = &label
+		}
+		if label, ok := labels[e.To()]; ok {
+			to = &label
+		}
+		violations = append(violations, schema.checkEdge(e.Id(), e.Label(), from, to, e.IsDirected(), e.Properties())...)
+	}
+	return violations
+}
@@ -0,0 +1,119 @@
+package mgraph
+
+import (
+	"errors"
+	"testing"
+)
+
+func newCatalogSchema() *Schema {
+	return &Schema{
+		Vertices: map[string]VertexSchema{
+			"user":    {Properties: map[string]PropertyType{"username": StringProperty}},
+			"film":    {},
+			"chapter": {},
+			"genre":   {},
+		},
+		Edges: map[string]EdgeSchema{
+			"viewed":     {From: []string{"user"}, To: []string{"chapter", "film"}, Properties: map[string]PropertyType{"rating": FloatProperty}},
+			"isFriendOf": {From: []string{"user"}, To: []string{"user"}},
+			"isOfGenre":  {To: []string{"genre"}},
+		},
+	}
+}
+
+func newSchemaGraph() Graph {
+	g := New(WithSchema(newCatalogSchema()), WithOrdering(InsertionOrder), WithDirectedness(Mixed))
+	_, _ = g.AddVertex("alice", WithLabel("user"), WithProperty("username", "alice42"))
+	_, _ = g.AddVertex("bob", WithLabel("user"), WithProperty("username", "bobby"))
+	_, _ = g.AddVertex("alien", WithLabel("film"))
+	_, _ = g.AddVertex("sf", WithLabel("genre"))
+	return g
+}
+
+func TestGraph_Schema_AddVertex(t *testing.T) {
+	g := newSchemaGraph()
+	var violation *Violation
+	_, err := g.AddVertex("carol", WithLabel("admin"))
+	if !errors.Is(err, ErrUndeclaredLabel) || !errors.As(err, &violation) || violation.Vertex != "carol" {
+		t.Fatalf("AddVertex() expected a violation ErrUndeclaredLabel, got: %v", err)
+	}
+	if _, err := g.AddVertex("carol", WithLabel("user")); !errors.Is(err, ErrMissingProperty) {
+		t.Fatalf("AddVertex() expected error ErrMissingProperty, got: %v", err)
+	}
+	if _, err := g.AddVertex("carol", WithLabel("user"), WithProperty("username", 42)); !errors.Is(err, ErrInvalidPropertyType) {
+		t.Fatalf("AddVertex() expected error ErrInvalidPropertyType, got: %v", err)
+	}
+	if g.Vertex("carol") != nil {
+		t.Fatalf("AddVertex() expected rejected vertex not to be added")
+	}
+}
+
+func TestGraph_Schema_AddEdge(t *testing.T) {
+	g := newSchemaGraph()
+	if _, err := g.AddEdge("e1", "alice", "alien", WithLabel("viewed"), WithProperty("rating", 4)); err != nil {
+		t.Fatalf("AddEdge() expected no error, got: %v", err)
+	}
+	if _, err := g.AddEdge("e2", "alien", "alice", WithLabel("viewed"), WithProperty("rating", 4)); !errors.Is(err, ErrInvalidEndpoints) {
+		t.Fatalf("AddEdge() expected error ErrInvalidEndpoints, got: %v", err)
+	}
+	if _, err := g.AddEdge("e2", "sf", "alien", WithLabel("isOfGenre")); !errors.Is(err, ErrInvalidEndpoints) {
+		t.Fatalf("AddEdge() expected error ErrInvalidEndpoints, got: %v", err)
+	}
+	if _, err := g.AddEdge("e2", "sf", "alien", WithLabel("isOfGenre"), UndirectedEdge()); err != nil {
+		t.Fatalf("AddEdge() expected undirected edge to connect labels either way, got: %v", err)
+	}
+	if _, err := g.AddEdge("e3", "alice", "bob", WithLabel("follows")); !errors.Is(err, ErrUndeclaredLabel) {
+		t.Fatalf("AddEdge() expected error ErrUndeclaredLabel, got: %v", err)
+	}
+	if _, err := g.AddEdge("e3", "bob", "alien", WithLabel("viewed"), WithProperty("rating", "good")); !errors.Is(err, ErrInvalidPropertyType) {
+		t.Fatalf("AddEdge() expected error ErrInvalidPropertyType, got: %v", err)
+	}
+}
+
+func TestGraph_Schema_Properties(t *testing.T) {
+	g := newSchemaGraph()
+	v := g.Vertex("alice")
+	if err := v.SetProperty("username", true); !errors.Is(err, ErrInvalidPropertyType) {
+		t.Fatalf("SetProperty() expected error ErrInvalidPropertyType, got: %v", err)
+	}
+	if err := v.RemoveProperty("username"); !errors.Is(err, ErrMissingProperty) {
+		t.Fatalf("RemoveProperty() expected error ErrMissingProperty, got: %v", err)
+	}
+	if value, _ := v.Property("username"); value != "alice42" {
+		t.Fatalf("Property() expected alice42 to be kept, got: %v", value)
+	}
+	if err := v.SetProperty("nickname", 7); err != nil {
+		t.Fatalf("SetProperty() expected undeclared property to be allowed, got: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	g := New(WithOrdering(InsertionOrder))
+	_, _ = g.AddVertex("alice", WithLabel("user"))
+	_, _ = g.AddVertex("alien", WithLabel("film"))
+	_, _ = g.AddVertex("x")
+	_, _ = g.AddEdge("e1", "alien", "alice", WithLabel("viewed"))
+	if violations := Validate(g); violations != nil {
+		t.Fatalf("Validate() expected no violations without schema, got: %v", violations)
+	}
+	g.EnforceSchema(newCatalogSchema())
+	violations := Validate(g)
+	expected := []struct {
+		vertex VertexID
+		edge   EdgeID
+		err    error
+	}{
+		{vertex: "alice", err: ErrMissingProperty},
+		{vertex: "x", err: ErrUndeclaredLabel},
+		{edge: "e1", err: ErrInvalidEndpoints},
+		{edge: "e1", err: ErrMissingProperty},
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Validate() expected %d violations, got: %v", len(expected), violations)
+	}
+	for i, violation := range violations {
+		if violation.Vertex != expected[i].vertex || violation.Edge != expected[i].edge || !errors.Is(&violation, expected[i].err) {
+			t.Fatalf("Validate() expected violation %v of '%s%s', got: %v", expected[i].err, expected[i].vertex, expected[i].edge, &violation)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package mgraph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrNoPath         = errors.New("no path between vertices")
+	ErrNegativeWeight = errors.New("negative edge weight")
+	ErrNegativeCycle  = errors.New("negative cycle detected")
+)
+
+// Weigher returns the cost of traversing an edge. A nil Weigher weighs every edge as 1.
+type Weigher[V, E any] func(e TypedEdge[V, E]) float64
+
+// Heuristic estimates the remaining cost from a vertex to the target of an A* search.
+type Heuristic func(v VertexID) float64
+
+func (w Weigher[V, E]) weight(e TypedEdge[V, E]) float64 {
+	if w == nil {
+		return 1
+	}
+	return w(e)
+}
+
+func Dijkstra[V, E any](g TypedView[V, E], from, to VertexID, weigher Weigher[V, E]) ([]TypedEdge[V, E], float64, error) {
+	return AStar(g, from, to, weigher, nil)
+}
+
+func AStar[V, E any](g TypedView[V, E], from, to VertexID, weigher Weigher[V, E], heuristic Heuristic) ([]TypedEdge[V, E], float64, error) {
+	if err := checkEndpoints(g, from, to); err != nil {
+		return nil, 0, err
+	}
+	h := func(id VertexID) float64 {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(id)
+	}
+	dist := map[VertexID]float64{from: 0}
+	prev := map[VertexID]TypedEdge[V, E]{}
+	queue := &priorityQueue{{id: from, priority: h(from)}}
+	for queue.Len() > 0 {
+		it := heap.Pop(queue).(queueItem)
+		if it.id == to {
+			return buildPath(prev, from, to), dist[to], nil
+		}
+		if it.priority > dist[it.id]+h(it.id) {
+			continue
+		}
+		v := g.Vertex(it.id)
+		if v == nil {
+			continue
+		}
+		for _, e := range v.Outgoing() {
+			w := weigher.weight(e)
+			if w < 0 {
+				return nil, 0, fmt.Errorf("error while searching path from '%s' to '%s': edge '%s': %w", from, to, e.Id(), ErrNegativeWeight)
+			}
+			d, next := dist[it.id]+w, opposite(e, it.id)
+			if old, ok := dist[next]; ok && old <= d {
+				continue
+			}
+			dist[next] = d
+			prev[next] = e
+			heap.Push(queue, queueItem{id: next, priority: d + h(next)})
+		}
+	}
+	return nil, 0, fmt.Errorf("error while searching path from '%s' to '%s': %w", from, to, ErrNoPath)
+}
+
+// BellmanFord accepts negative weights and fails with ErrNegativeCycle when a negative cycle is reachable from the source.
+// An undirected edge with a negative weight is such a cycle on its own.
+func BellmanFord[V, E any](g TypedView[V, E], from, to VertexID, weigher Weigher[V, E]) ([]TypedEdge[V, E], float64, error) {
+	if err := checkEndpoints(g, from, to); err != nil {
+		return nil, 0, err
+	}
+	dist := map[VertexID]float64{from: 0}
+	prev := map[VertexID]TypedEdge[V, E]{}
+	edges := g.Edges()
+	relax := func() (changed TypedEdge[V, E]) {
+		for _, e := range edges {
+			for _, at := range [2]VertexID{e.From(), e.To()} {
+				d, ok := dist[at]
+				if !ok || e.IsDirected() && at != e.From() {
+					continue
+				}
+				d += weigher.weight(e)
+				next := opposite(e, at)
+				if old, ok := dist[next]; !ok || d < old {
+					dist[next] = d
+					prev[next] = e
+					changed = e
+				}
+			}
+		}
+		return
+	}
+	for i := 1; i < g.Order(); i++ {
+		if relax() == nil {
+			break
+		}
+	}
+	if e := relax(); e != nil {
+		return nil, 0, fmt.Errorf("error while searching path from '%s' to '%s': edge '%s': %w", from, to, e.Id(), ErrNegativeCycle)
+	}
+	d, ok := dist[to]
+	if !ok {
+		return nil, 0, fmt.Errorf("error while searching path from '%s' to '%s': %w", from, to, ErrNoPath)
+	}
+	return buildPath(prev, from, to), d, nil
+}
+
+func checkEndpoints[V, E any](g TypedView[V, E], from, to VertexID) error {
+	for _, id := range [2]VertexID{from, to} {
+		if g.Vertex(id) == nil {
+			return fmt.Errorf("error while searching path from '%s' to '%s': %w", from, to, fmt.Errorf("vertex '%s' does not exists: %w", id, ErrVertexDoesNotExists))
+		}
+	}
+	return nil
+}
+
+func buildPath[V, E any](prev map[VertexID]TypedEdge[V, E], from, to VertexID) []TypedEdge[V, E] {
+	path := []TypedEdge[V, E]{}
+	for at := to; at != from; at = opposite(prev[at], at) {
+		path = append(path, prev[at])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+type queueItem struct {
+	id       VertexID
+	priority float64
+}
+
+type priorityQueue []queueItem
+
+func (q priorityQueue) Len() int           { return len(q) }
+func (q priorityQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q priorityQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(x any)        { *q = append(*q, x.(queueItem)) }
+
+func (q *priorityQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,155 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type rated struct {
+	rating float64
+}
+
+func newWeightedGraph() TypedGraph[string, rated] {
+	g := NewTyped[string, rated]()
+	for _, id := range []VertexID{"node1", "node2", "node3", "node4", "node5"} {
+		_, _ = g.AddVertex(id)
+	}
+	add := func(id EdgeID, from, to VertexID, rating float64) {
+		e, _ := g.AddEdge(id, from, to)
+		e.StoreData(rated{rating: rating})
+	}
+	add("edge1", "node1", "node2", 7)
+	add("edge2", "node1", "node2", 2)
+	add("edge3", "node2", "node3", 3)
+	add("edge4", "node1", "node3", 6)
+	add("edge5", "node3", "node4", 1)
+	return g
+}
+
+func byRating(e TypedEdge[string, rated]) float64 {
+	return e.Data().rating
+}
+
+func edgeIDs[V, E any](edges []TypedEdge[V, E]) []EdgeID {
+	ids := make([]EdgeID, 0, len(edges))
+	for _, e := range edges {
+		ids = append(ids, e.Id())
+	}
+	return ids
+}
+
+func TestDijkstra(t *testing.T) {
+	g := newWeightedGraph()
+	path, cost, err := Dijkstra(g, "node1", "node4", byRating)
+	if err != nil {
+		t.Fatalf("Dijkstra() expected no error, got: %v", err)
+	}
+	if !slices.Equal(edgeIDs(path), []EdgeID{"edge2", "edge3", "edge5"}) {
+		t.Fatalf("Dijkstra() expected path [edge2 edge3 edge5], got: %v", edgeIDs(path))
+	}
+	if cost != 6 {
+		t.Fatalf("Dijkstra() expected cost 6, got: %v", cost)
+	}
+}
+
+func TestDijkstra_WithNilWeigher(t *testing.T) {
+	g := newWeightedGraph()
+	path, cost, _ := Dijkstra(g, "node1", "node4", nil)
+	if !slices.Equal(edgeIDs(path), []EdgeID{"edge4", "edge5"}) || cost != 2 {
+		t.Fatalf("Dijkstra() expected path [edge4 edge5] with cost 2, got: %v %v", edgeIDs(path), cost)
+	}
+}
+
+func TestDijkstra_Errors(t *testing.T) {
+	g := newWeightedGraph()
+	_, _, err := Dijkstra(g, "node1", "node5", byRating)
+	if !errors.Is(err, ErrNoPath) {
+		t.Fatalf("Dijkstra() expected error ErrNoPath, got: %v", err)
+	}
+	_, _, err = Dijkstra(g, "node1", "node9", byRating)
+	if !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("Dijkstra() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+	g.Edge("edge2").StoreData(rated{rating: -1})
+	_, _, err = Dijkstra(g, "node1", "node4", byRating)
+	if !errors.Is(err, ErrNegativeWeight) {
+		t.Fatalf("Dijkstra() expected error ErrNegativeWeight, got: %v", err)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	g := newWeightedGraph()
+	path, cost, err := AStar(g, "node1", "node4", byRating, func(v VertexID) float64 {
+		if v == "node4" {
+			return 0
+		}
+		return 1
+	})
+	if err != nil {
+		t.Fatalf("AStar() expected no error, got: %v", err)
+	}
+	if !slices.Equal(edgeIDs(path), []EdgeID{"edge2", "edge3", "edge5"}) || cost != 6 {
+		t.Fatalf("AStar() expected path [edge2 edge3 edge5] with cost 6, got: %v %v", edgeIDs(path), cost)
+	}
+}
+
+func TestBellmanFord_WithNegativeWeights(t *testing.T) {
+	g := newWeightedGraph()
+	_, _ = g.AddVertex("node6")
+	e, _ := g.AddEdge("edge6", "node1", "node6")
+	e.StoreData(rated{rating: 10})
+	e, _ = g.AddEdge("edge7", "node6", "node4")
+	e.StoreData(rated{rating: -8})
+	path, cost, err := BellmanFord(g, "node1", "node4", byRating)
+	if err != nil {
+		t.Fatalf("BellmanFord() expected no error, got: %v", err)
+	}
+	if !slices.Equal(edgeIDs(path), []EdgeID{"edge6", "edge7"}) || cost != 2 {
+		t.Fatalf("BellmanFord() expected path [edge6 edge7] with cost 2, got: %v %v", edgeIDs(path), cost)
+	}
+}
+
+func TestBellmanFord_ErrorNegativeCycle(t *testing.T) {
+	g := newWeightedGraph()
+	e, _ := g.AddEdge("edge6", "node3", "node1")
+	e.StoreData(rated{rating: -6})
+	_, _, err := BellmanFord(g, "node1", "node4", byRating)
+	if !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("BellmanFord() expected error ErrNegativeCycle, got: %v", err)
+	}
+}
+
+func TestBellmanFord_ErrorNegativeLoop(t *testing.T) {
+	g := newWeightedGraph()
+	e, _ := g.AddEdge("edge6", "node2", "node2")
+	e.StoreData(rated{rating: -1})
+	if _, _, err := BellmanFord(g, "node1", "node4", byRating); !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("BellmanFord() expected error ErrNegativeCycle, got: %v", err)
+	}
+}
+
+func TestDijkstraAndBellmanFord_OnMixedGraph(t *testing.T) {
+	g := NewTyped[string, rated](WithDirectedness(Mixed))
+	for _, id := range []VertexID{"node1", "node2", "node3"} {
+		_, _ = g.AddVertex(id)
+	}
+	e, _ := g.AddEdge("edge1", "node2", "node1", UndirectedEdge())
+	e.StoreData(rated{rating: 1})
+	e, _ = g.AddEdge("edge2", "node3", "node2")
+	e.StoreData(rated{rating: 1})
+	path, _, err := Dijkstra(g, "node1", "node2", byRating)
+	if err != nil || !slices.Equal(edgeIDs(path), []EdgeID{"edge1"}) {
+		t.Fatalf("Dijkstra() expected path [edge1], got: %v %v", edgeIDs(path), err)
+	}
+	path, _, err = BellmanFord(g, "node1", "node2", byRating)
+	if err != nil || !slices.Equal(edgeIDs(path), []EdgeID{"edge1"}) {
+		t.Fatalf("BellmanFord() expected path [edge1], got: %v %v", edgeIDs(path), err)
+	}
+	if _, _, err = Dijkstra(g, "node1", "node3", byRating); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("Dijkstra() expected error ErrNoPath, got: %v", err)
+	}
+	if _, _, err = BellmanFord(g, "node1", "node3", byRating); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("BellmanFord() expected error ErrNoPath, got: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package mgraph
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Kruskal returns the edges of a minimum spanning forest of the graph, a minimum
+// spanning tree of each of its connected components, along with their total weight.
+// Edges are treated as undirected; loops and edges to missing vertices are ignored.
+// Among edges of equal weight, the first one in the graph order is chosen.
+func Kruskal[V, E any](g TypedView[V, E], weigher Weigher[V, E]) ([]EdgeID, float64) {
+	sets := disjointSets{}
+	for v := range g.AllVertices() {
+		sets[v.Id()] = v.Id()
+	}
+	type candidate struct {
+		e      TypedEdge[V, E]
+		weight float64
+	}
+	var candidates []candidate
+	for e := range g.AllEdges() {
+		_, okFrom := sets[e.From()]
+		_, okTo := sets[e.To()]
+		if okFrom && okTo && !e.IsLoop() {
+			candidates = append(candidates, candidate{e: e, weight: weigher.weight(e)})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.weight < b.weight:
+			return -1
+		case a.weight > b.weight:
+			return 1
+		}
+		return 0
+	})
+	edges := []EdgeID{}
+	var total float64
+	for _, c := range candidates {
+		if sets.union(c.e.From(), c.e.To()) {
+			edges = append(edges, c.e.Id())
+			total += c.weight
+		}
+	}
+	return edges, total
+}
+
+// Prim returns a minimum spanning forest of the same weight as Kruskal, growing a
+// tree from each vertex, in the graph order, that is not spanned yet. Between two
+// vertices, only the cheapest of their parallel edges is considered.
+func Prim[V, E any](g TypedView[V, E], weigher Weigher[V, E]) ([]EdgeID, float64) {
+	spanned := map[VertexID]bool{}
+	edges := []EdgeID{}
+	var total float64
+	for root := range g.AllVertices() {
+		if spanned[root.Id()] {
+			continue
+		}
+		cost := map[VertexID]float64{root.Id(): 0}
+		via := map[VertexID]TypedEdge[V, E]{}
+		queue := &priorityQueue{{id: root.Id()}}
+		for queue.Len() > 0 {
+			it := heap.Pop(queue).(queueItem)
+			if spanned[it.id] || it.priority > cost[it.id] {
+				continue
+			}
+			v := g.Vertex(it.id)
+			if v == nil {
+				continue
+			}
+			spanned[it.id] = true
+			if e, ok := via[it.id]; ok {
+				edges = append(edges, e.Id())
+				total += it.priority
+			}
+			for _, e := range cheapestEdges(v, weigher) {
+				n := opposite(e, it.id)
+				if spanned[n] {
+					continue
+				}
+				w := weigher.weight(e)
+				if old, ok := cost[n]; ok && old <= w {
+					continue
+				}
+				cost[n] = w
+				via[n] = e
+				heap.Push(queue, queueItem{id: n, priority: w})
+			}
+		}
+	}
+	return edges, total
+}
+
+// cheapestEdges returns the cheapest edge between a vertex and each of its
+// neighbors, whatever the direction of the edges, in the order of the edges.
+func cheapestEdges[V, E any](v TypedVertex[V, E], weigher Weigher[V, E]) []TypedEdge[V, E] {
+	var cheapest []TypedEdge[V, E]
+	index := map[VertexID]int{}
+	for _, e := range v.Edges() {
+		if e.IsLoop() {
+			continue
+		}
+		n := opposite(e, v.Id())
+		if i, ok := index[n]; !ok {
+			index[n] = len(cheapest)
+			cheapest = append(cheapest, e)
+		} else if weigher.weight(e) < weigher.weight(cheapest[i]) {
+			cheapest[i] = e
+		}
+	}
+	return cheapest
+}
+
+// SpanningForest returns a new undirected graph with all the vertices of the graph
+// and the given edges, such as the ones returned by Kruskal or Prim. Labels,
+// properties, validity and data are copied, the data by assignment.
+func SpanningForest[V, E any](g TypedView[V, E], edges []EdgeID) TypedGraph[V, E] {
+	forest := NewTyped[V, E](WithDirectedness(Undirected), WithOrdering(InsertionOrder))
+	for v := range g.AllVertices() {
+		copied, _ := forest.AddVertex(v.Id(), copyOptions(v.Label(), v.Properties(), v.Validity())...)
+		copied.StoreData(v.Data())
+	}
+	for _, id := range edges {
+		e := g.Edge(id)
+		if e == nil {
+			continue
+		}
+		copied, err := forest.AddEdge(id, e.From(), e.To(), copyOptions(e.Label(), e.Properties(), e.Validity())...)
+		if err == nil {
+			copied.StoreData(e.Data())
+		}
+	}
+	return forest
+}
+
+func copyOptions(label string, properties map[string]any, validity Interval) []ElementOption {
+	opts := []ElementOption{WithLabel(label), WithValidity(validity.From, validity.To)}
+	for property, value := range properties {
+		opts = append(opts, WithProperty(property, value))
+	}
+	return opts
+}
+
+// disjointSets is a union-find over vertices, mapping each vertex to its parent.
+type disjointSets map[VertexID]VertexID
+
+func (s disjointSets) find(id VertexID) VertexID {
+	for s[id] != id {
+		s[id] = s[s[id]]
+		id = s[id]
+	}
+	return id
+}
+
+// union merges the sets of two vertices, and reports whether they were disjoint.
+func (s disjointSets) union(a, b VertexID) bool {
+	a, b = s.find(a), s.find(b)
+	if a == b {
+		return false
+	}
+	s[b] = a
+	return true
+}
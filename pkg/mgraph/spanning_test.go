@@ -0,0 +1,65 @@
+package mgraph
+
+import (
+	"slices"
+	"testing"
+)
+
+func newSpanningGraph() Graph {
+	g := New(WithDirectedness(Directed), WithOrdering(InsertionOrder))
+	for _, id := range []VertexID{"a", "b", "c", "d", "e", "f", "g"} {
+		_, _ = g.AddVertex(id, WithLabel("film"))
+	}
+	for _, e := range []struct {
+		id       EdgeID
+		from, to VertexID
+		weight   float64
+	}{
+		{"ab", "a", "b", 4}, {"ba", "b", "a", 1}, {"ac", "a", "c", 3}, {"bc", "b", "c", 2},
+		{"cd", "c", "d", 5}, {"bd", "b", "d", 6}, {"dd", "d", "d", 0},
+		{"ef", "e", "f", 2}, {"fe", "f", "e", 7},
+	} {
+		_, _ = g.AddEdge(e.id, e.from, e.to, WithProperty("weight", e.weight))
+	}
+	return g
+}
+
+func byWeight(e Edge) float64 {
+	w, _ := e.Property("weight")
+	return w.(float64)
+}
+
+func TestKruskal(t *testing.T) {
+	g := newSpanningGraph()
+	edges, total := Kruskal(g, byWeight)
+	if !slices.Equal(edges, []EdgeID{"ba", "bc", "ef", "cd"}) || total != 10 {
+		t.Fatalf("Kruskal() expected [ba bc ef cd] of weight 10, got: %v of weight %v", edges, total)
+	}
+	if _, total := Kruskal(g, nil); total != 4 {
+		t.Fatalf("Kruskal() expected a forest of 4 edges weighing 1, got: %v", total)
+	}
+}
+
+func TestPrim(t *testing.T) {
+	g := newSpanningGraph()
+	edges, total := Prim(g, byWeight)
+	slices.Sort(edges)
+	if !slices.Equal(edges, []EdgeID{"ba", "bc", "cd", "ef"}) || total != 10 {
+		t.Fatalf("Prim() expected [ba bc cd ef] of weight 10, got: %v of weight %v", edges, total)
+	}
+}
+
+func TestSpanningForest(t *testing.T) {
+	g := newSpanningGraph()
+	edges, _ := Kruskal(g, byWeight)
+	forest := SpanningForest(g, edges)
+	if forest.Order() != 7 || forest.Size() != 4 || forest.OrderByLabel("film") != 7 {
+		t.Fatalf("SpanningForest() expected 7 vertices and 4 edges, got: %d and %d", forest.Order(), forest.Size())
+	}
+	if e := forest.Edge("ba"); e.IsDirected() || byWeight(e) != 1 {
+		t.Fatalf("SpanningForest() expected undirected copies of the edges")
+	}
+	if c := WeaklyConnectedComponents(forest); len(c.Components) != 3 {
+		t.Fatalf("SpanningForest() expected 3 trees, got: %v", c.Components)
+	}
+}
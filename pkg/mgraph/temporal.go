@@ -0,0 +1,374 @@
+package mgraph
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Interval is the interval of time [From, To) during which a vertex or an edge
+// holds. A zero From or To leaves that side open, so the zero Interval always
+// holds.
+type Interval struct {
+	From time.Time
+	To   time.Time
+}
+
+func (i Interval) Contains(t time.Time) bool {
+	return (i.From.IsZero() || !t.Before(i.From)) && (i.To.IsZero() || t.Before(i.To))
+}
+
+// Overlaps reports whether both intervals share an instant.
+func (i Interval) Overlaps(other Interval) bool {
+	return !i.empty() && !other.empty() && before(i.From, other.To) && before(other.From, i.To)
+}
+
+func (i Interval) empty() bool {
+	return !before(i.From, i.To)
+}
+
+// before compares a lower bound to an upper bound, either of them possibly open.
+func before(from, to time.Time) bool {
+	return from.IsZero() || to.IsZero() || from.Before(to)
+}
+
+// AsOf returns a view of the graph restricted to the vertices holding at the given
+// time and to the edges holding at that time between them. The view follows the
+// changes of the graph, and its vertices and edges, which belong to the graph,
+// mutate it.
+func (g *graph[V, E]) AsOf(t time.Time) TypedView[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return asOf[V, E](g, g.properties, t)
+}
+
+// Between returns a view of the graph restricted to the vertices holding at some
+// time in [from, to) and to the edges between them holding at some time in it.
+func (g *graph[V, E]) Between(from, to time.Time) TypedView[V, E] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return between[V, E](g, g.properties, from, to)
+}
+
+func (p *persistent[V, E]) AsOf(t time.Time) TypedView[V, E] {
+	return asOf[V, E](p, p.properties, t)
+}
+
+func (p *persistent[V, E]) Between(from, to time.Time) TypedView[V, E] {
+	return between[V, E](p, p.properties, from, to)
+}
+
+func asOf[V, E any](g TypedView[V, E], properties properties, t time.Time) *temporalView[V, E] {
+	return &temporalView[V, E]{TypedView: g, properties: properties, holds: func(i Interval) bool { return i.Contains(t) }}
+}
+
+func between[V, E any](g TypedView[V, E], properties properties, from, to time.Time) *temporalView[V, E] {
+	window := Interval{From: from, To: to}
+	return &temporalView[V, E]{TypedView: g, properties: properties, holds: func(i Interval) bool { return i.Overlaps(window) }}
+}
+
+// temporalView filters the vertices and edges of a graph by their validity. Edges
+// are only visible along with their endpoints.
+type temporalView[V, E any] struct {
+	TypedView[V, E]
+	properties properties
+	holds      func(i Interval) bool
+}
+
+type temporalVertex[V, E any] struct {
+	TypedVertex[V, E]
+	view *temporalView[V, E]
+}
+
+type temporalEdge[V, E any] struct {
+	TypedEdge[V, E]
+	view *temporalView[V, E]
+}
+
+type temporalSnapshot[V, E any] struct {
+	*temporalView[V, E]
+	snapshot TypedSnapshot[V, E]
+}
+
+func (w *temporalView[V, E]) visibleVertex(v TypedVertex[V, E]) bool {
+	return v != nil && w.holds(v.Validity())
+}
+
+func (w *temporalView[V, E]) visibleEdge(e TypedEdge[V, E]) bool {
+	if e == nil || !w.holds(e.Validity()) {
+		return false
+	}
+	for _, id := range [2]VertexID{e.From(), e.To()} {
+		if v := w.TypedView.Vertex(id); v != nil && !w.holds(v.Validity()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *temporalView[V, E]) vertex(v TypedVertex[V, E]) TypedVertex[V, E] {
+	if !w.visibleVertex(v) {
+		return nil
+	}
+	return &temporalVertex[V, E]{TypedVertex: v, view: w}
+}
+
+func (w *temporalView[V, E]) edge(e TypedEdge[V, E]) TypedEdge[V, E] {
+	if !w.visibleEdge(e) {
+		return nil
+	}
+	return &temporalEdge[V, E]{TypedEdge: e, view: w}
+}
+
+func (w *temporalView[V, E]) vertices(vertices []TypedVertex[V, E]) []TypedVertex[V, E] {
+	return wrapVertices(vertices, w.vertex)
+}
+
+func (w *temporalView[V, E]) edges(edges []TypedEdge[V, E]) []TypedEdge[V, E] {
+	return wrapEdges(edges, w.edge)
+}
+
+func (w *temporalView[V, E]) vertexEntries(entries iter.Seq2[VertexID, TypedVertex[V, E]]) iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return wrapVertexEntries(entries, w.vertex)
+}
+
+func (w *temporalView[V, E]) edgeEntries(entries iter.Seq2[EdgeID, TypedEdge[V, E]]) iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return wrapEdgeEntries(entries, w.edge)
+}
+
+func (w *temporalView[V, E]) Vertex(id VertexID) TypedVertex[V, E] {
+	return w.vertex(w.TypedView.Vertex(id))
+}
+
+func (w *temporalView[V, E]) Vertices() []TypedVertex[V, E] {
+	return w.vertices(w.TypedView.Vertices())
+}
+
+func (w *temporalView[V, E]) ForEachVertex(each func(v TypedVertex[V, E]) bool) {
+	for v := range w.AllVertices() {
+		if !each(v) {
+			return
+		}
+	}
+}
+
+func (w *temporalView[V, E]) AllVertices() iter.Seq[TypedVertex[V, E]] {
+	return values(w.VertexEntries())
+}
+
+func (w *temporalView[V, E]) VertexEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return w.vertexEntries(w.TypedView.VertexEntries())
+}
+
+func (w *temporalView[V, E]) Edge(id EdgeID) TypedEdge[V, E] {
+	return w.edge(w.TypedView.Edge(id))
+}
+
+func (w *temporalView[V, E]) Edges() []TypedEdge[V, E] {
+	return w.edges(w.TypedView.Edges())
+}
+
+func (w *temporalView[V, E]) ForEachEdge(each func(e TypedEdge[V, E]) bool) {
+	for e := range w.AllEdges() {
+		if !each(e) {
+			return
+		}
+	}
+}
+
+func (w *temporalView[V, E]) AllEdges() iter.Seq[TypedEdge[V, E]] {
+	return values(w.EdgeEntries())
+}
+
+func (w *temporalView[V, E]) EdgeEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return w.edgeEntries(w.TypedView.EdgeEntries())
+}
+
+func (w *temporalView[V, E]) EdgesBetween(from, to VertexID) []TypedEdge[V, E] {
+	return w.edges(w.TypedView.EdgesBetween(from, to))
+}
+
+func (w *temporalView[V, E]) VerticesByLabel(label string) []TypedVertex[V, E] {
+	return w.vertices(w.TypedView.VerticesByLabel(label))
+}
+
+func (w *temporalView[V, E]) EdgesByLabel(label string) []TypedEdge[V, E] {
+	return w.edges(w.TypedView.EdgesByLabel(label))
+}
+
+func (w *temporalView[V, E]) EdgesBetweenByLabel(from, to VertexID, label string) []TypedEdge[V, E] {
+	return w.edges(w.TypedView.EdgesBetweenByLabel(from, to, label))
+}
+
+func (w *temporalView[V, E]) OrderByLabel(label string) int {
+	return len(w.VerticesByLabel(label))
+}
+
+func (w *temporalView[V, E]) SizeByLabel(label string) int {
+	return len(w.EdgesByLabel(label))
+}
+
+func (w *temporalView[V, E]) VertexLabels() []string {
+	labels := map[string]bool{}
+	for v := range w.AllVertices() {
+		if v.Label() != "" {
+			labels[v.Label()] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(labels))
+}
+
+func (w *temporalView[V, E]) EdgeLabels() []string {
+	labels := map[string]bool{}
+	for e := range w.AllEdges() {
+		if e.Label() != "" {
+			labels[e.Label()] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(labels))
+}
+
+func (w *temporalView[V, E]) VerticesByProperty(property string, value any) []TypedVertex[V, E] {
+	return w.vertices(w.TypedView.VerticesByProperty(property, value))
+}
+
+func (w *temporalView[V, E]) EdgesByProperty(property string, value any) []TypedEdge[V, E] {
+	return w.edges(w.TypedView.EdgesByProperty(property, value))
+}
+
+func (w *temporalView[V, E]) VerticesByPropertyRange(property string, from, to any) ([]TypedVertex[V, E], error) {
+	vertices, err := w.TypedView.VerticesByPropertyRange(property, from, to)
+	return w.vertices(vertices), err
+}
+
+func (w *temporalView[V, E]) EdgesByPropertyRange(property string, from, to any) ([]TypedEdge[V, E], error) {
+	edges, err := w.TypedView.EdgesByPropertyRange(property, from, to)
+	return w.edges(edges), err
+}
+
+func (w *temporalView[V, E]) Order() (order int) {
+	for range w.AllVertices() {
+		order++
+	}
+	return
+}
+
+func (w *temporalView[V, E]) Size() (size int) {
+	for range w.AllEdges() {
+		size++
+	}
+	return
+}
+
+func (w *temporalView[V, E]) Degree() (degree int) {
+	for v := range w.AllVertices() {
+		degree = max(degree, v.Degree())
+	}
+	return
+}
+
+func (w *temporalView[V, E]) Clone() TypedGraph[V, E] {
+	return w.CloneWith(DataCloner[V, E]{})
+}
+
+// CloneWith returns a mutable copy of the vertices and edges of the view.
+func (w *temporalView[V, E]) CloneWith(cloner DataCloner[V, E]) TypedGraph[V, E] {
+	return freeze(w, w.properties).CloneWith(cloner)
+}
+
+func (w *temporalView[V, E]) At(version uint64) (TypedView[V, E], error) {
+	g, err := w.TypedView.At(version)
+	if err != nil {
+		return nil, err
+	}
+	return &temporalView[V, E]{TypedView: g, properties: w.properties, holds: w.holds}, nil
+}
+
+func (w *temporalView[V, E]) Snapshot() (TypedSnapshot[V, E], error) {
+	s, err := w.TypedView.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &temporalSnapshot[V, E]{temporalView: &temporalView[V, E]{TypedView: s, properties: w.properties, holds: w.holds}, snapshot: s}, nil
+}
+
+func (w *temporalView[V, E]) AsOf(t time.Time) TypedView[V, E] {
+	return asOf[V, E](w, w.properties, t)
+}
+
+func (w *temporalView[V, E]) Between(from, to time.Time) TypedView[V, E] {
+	return between[V, E](w, w.properties, from, to)
+}
+
+func (s *temporalSnapshot[V, E]) Release() {
+	s.snapshot.Release()
+}
+
+func (v *temporalVertex[V, E]) Incoming() []TypedEdge[V, E] {
+	return v.view.edges(v.TypedVertex.Incoming())
+}
+
+func (v *temporalVertex[V, E]) Outgoing() []TypedEdge[V, E] {
+	return v.view.edges(v.TypedVertex.Outgoing())
+}
+
+func (v *temporalVertex[V, E]) Edges() []TypedEdge[V, E] {
+	return v.view.edges(v.TypedVertex.Edges())
+}
+
+func (v *temporalVertex[V, E]) IncomingByLabel(label string) []TypedEdge[V, E] {
+	return v.view.edges(v.TypedVertex.IncomingByLabel(label))
+}
+
+func (v *temporalVertex[V, E]) OutgoingByLabel(label string) []TypedEdge[V, E] {
+	return v.view.edges(v.TypedVertex.OutgoingByLabel(label))
+}
+
+func (v *temporalVertex[V, E]) In() iter.Seq[TypedEdge[V, E]] {
+	return values(v.InEntries())
+}
+
+func (v *temporalVertex[V, E]) InEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.view.edgeEntries(v.TypedVertex.InEntries())
+}
+
+func (v *temporalVertex[V, E]) Out() iter.Seq[TypedEdge[V, E]] {
+	return values(v.OutEntries())
+}
+
+func (v *temporalVertex[V, E]) OutEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return v.view.edgeEntries(v.TypedVertex.OutEntries())
+}
+
+func (v *temporalVertex[V, E]) Neighbors() iter.Seq[TypedVertex[V, E]] {
+	return values(v.NeighborEntries())
+}
+
+func (v *temporalVertex[V, E]) NeighborEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return neighborEntries[V, E](v.view, v.Id(), v.OutEntries(), v.InEntries())
+}
+
+// Degree counts loops twice and every other visible incident edge once.
+func (v *temporalVertex[V, E]) Degree() int {
+	edges := v.Edges()
+	degree := len(edges)
+	for _, e := range edges {
+		if e.IsLoop() {
+			degree++
+		}
+	}
+	return degree
+}
+
+func (e *temporalEdge[V, E]) Tail() TypedVertex[V, E] {
+	return e.view.Vertex(e.From())
+}
+
+func (e *temporalEdge[V, E]) Head() TypedVertex[V, E] {
+	return e.view.Vertex(e.To())
+}
+
+func (e *temporalEdge[V, E]) Endpoints() [2]TypedVertex[V, E] {
+	return [2]TypedVertex[V, E]{e.Tail(), e.Head()}
+}
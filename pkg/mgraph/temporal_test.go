@@ -0,0 +1,161 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func hour(h int) time.Time {
+	return time.Date(2024, 1, 1, h, 0, 0, 0, time.UTC)
+}
+
+func newTemporalGraph() Graph {
+	g := New(WithDirectedness(Directed), WithOrdering(InsertionOrder))
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddVertex("c", WithValidity(hour(0), hour(10)))
+	_, _ = g.AddEdge("ab1", "a", "b", WithValidity(hour(0), hour(2)))
+	_, _ = g.AddEdge("ab2", "a", "b", WithValidity(hour(1), hour(3)))
+	_, _ = g.AddEdge("bc", "b", "c", WithValidity(hour(4), time.Time{}))
+	_, _ = g.AddEdge("ca", "c", "a")
+	return g
+}
+
+func TestInterval(t *testing.T) {
+	i := Interval{From: hour(1), To: hour(3)}
+	if !i.Contains(hour(1)) || i.Contains(hour(3)) || i.Contains(hour(0)) {
+		t.Fatalf("Contains() expected [from, to) semantics")
+	}
+	if !(Interval{}).Contains(hour(5)) || !(Interval{To: hour(3)}).Contains(hour(-100)) {
+		t.Fatalf("Contains() expected zero bounds to be open")
+	}
+	for _, c := range []struct {
+		other    Interval
+		expected bool
+	}{
+		{Interval{From: hour(2), To: hour(5)}, true},
+		{Interval{From: hour(3), To: hour(5)}, false},
+		{Interval{To: hour(1)}, false},
+		{Interval{From: hour(0)}, true},
+		{Interval{From: hour(2), To: hour(2)}, false},
+	} {
+		if got := i.Overlaps(c.other); got != c.expected {
+			t.Fatalf("Overlaps(%v) expected %v, got: %v", c.other, c.expected, got)
+		}
+	}
+}
+
+func TestGraph_AsOf(t *testing.T) {
+	g := newTemporalGraph()
+	view := g.AsOf(hour(1))
+	if edges := edgeIDs(view.Edges()); !slices.Equal(edges, []EdgeID{"ab1", "ab2", "ca"}) {
+		t.Fatalf("Edges() expected [ab1 ab2 ca], got: %v", edges)
+	}
+	if edges := edgeIDs(view.EdgesBetween("a", "b")); !slices.Equal(edges, []EdgeID{"ab1", "ab2"}) {
+		t.Fatalf("EdgesBetween() expected [ab1 ab2], got: %v", edges)
+	}
+	if view.Edge("bc") != nil || view.Size() != 3 {
+		t.Fatalf("Edge() expected bc to be hidden")
+	}
+	later := g.AsOf(hour(12))
+	if later.Vertex("c") != nil || later.Order() != 2 {
+		t.Fatalf("Vertex() expected c to be hidden")
+	}
+	if edges := edgeIDs(later.Edges()); len(edges) != 0 {
+		t.Fatalf("Edges() expected the edges of c to be hidden with it, got: %v", edges)
+	}
+	b := later.Vertex("b")
+	if edges := edgeIDs(b.Edges()); len(edges) != 0 || b.Degree() != 0 {
+		t.Fatalf("Edges() expected no edges for b, got: %v", edges)
+	}
+	var neighbors []VertexID
+	for n := range g.AsOf(hour(5)).Vertex("b").Neighbors() {
+		neighbors = append(neighbors, n.Id())
+	}
+	if !slices.Equal(neighbors, []VertexID{"c"}) {
+		t.Fatalf("Neighbors() expected [c], got: %v", neighbors)
+	}
+	_, _ = g.AddEdge("ac", "a", "c", WithValidity(hour(1), hour(2)))
+	if e := view.Edge("ac"); e == nil || e.Head().Graph() != g {
+		t.Fatalf("AsOf() expected a live view of the graph")
+	}
+}
+
+func TestGraph_Between(t *testing.T) {
+	g := newTemporalGraph()
+	view := g.Between(hour(2), hour(4))
+	if edges := edgeIDs(view.Edges()); !slices.Equal(edges, []EdgeID{"ab2", "ca"}) {
+		t.Fatalf("Edges() expected [ab2 ca], got: %v", edges)
+	}
+	if edges := edgeIDs(view.AsOf(hour(3)).Edges()); !slices.Equal(edges, []EdgeID{"ca"}) {
+		t.Fatalf("AsOf() expected views to compose, got: %v", edges)
+	}
+	clone := view.Clone()
+	if edges := edgeIDs(clone.Edges()); !slices.Equal(edges, []EdgeID{"ab2", "ca"}) || clone.Order() != 3 {
+		t.Fatalf("Clone() expected a copy of the view, got: %v", edges)
+	}
+	if frozen := Freeze(g).Between(hour(2), hour(4)); dumpGraph(frozen) != dumpGraph(view) {
+		t.Fatalf("Between() expected the same view on a persistent graph")
+	}
+}
+
+func TestGraph_SetValidity(t *testing.T) {
+	g := newTemporalGraph()
+	g.EnableHistory(10)
+	var events []Event
+	g.Subscribe(func(e Event) { events = append(events, e) })
+	if err := g.Edge("bc").SetValidity(hour(0), hour(1)); err != nil {
+		t.Fatalf("SetValidity() expected no error, got: %v", err)
+	}
+	_ = g.Vertex("c").SetValidity(time.Time{}, time.Time{})
+	if edges := edgeIDs(g.AsOf(hour(12)).Edges()); !slices.Equal(edges, []EdgeID{"ca"}) {
+		t.Fatalf("AsOf() expected [ca], got: %v", edges)
+	}
+	if described := describeEvents(events); !slices.Equal(described, []string{"@bc", "@c"}) {
+		t.Fatalf("Subscribe() expected events [@bc @c], got: %v", described)
+	}
+	_ = g.Undo()
+	if v := g.Vertex("c").Validity(); v != (Interval{From: hour(0), To: hour(10)}) {
+		t.Fatalf("Undo() expected the validity of c to be restored, got: %v", v)
+	}
+	tx := g.Begin()
+	_ = tx.Edge("bc").SetValidity(hour(5), hour(6))
+	_ = tx.Rollback()
+	if v := g.Edge("bc").Validity(); v != (Interval{From: hour(0), To: hour(1)}) {
+		t.Fatalf("Rollback() expected the validity of bc to be restored, got: %v", v)
+	}
+	if err := Freeze(g).Vertex("a").SetValidity(hour(0), hour(1)); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("SetValidity() expected error ErrReadOnly, got: %v", err)
+	}
+}
+
+func TestTemporalPath(t *testing.T) {
+	g := New(WithDirectedness(Directed))
+	for _, id := range []VertexID{"a", "b", "c", "d"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("ab", "a", "b", WithValidity(hour(1), hour(2)))
+	_, _ = g.AddEdge("bd", "b", "d", WithValidity(hour(0), hour(1)))
+	_, _ = g.AddEdge("ac", "a", "c", WithValidity(hour(3), hour(4)))
+	_, _ = g.AddEdge("cd", "c", "d", WithValidity(hour(3), hour(6)))
+	halfHour := func(Edge) time.Duration { return 30 * time.Minute }
+	path, arrival, err := TemporalPath(g, "a", "d", hour(0), halfHour)
+	if err != nil || !slices.Equal(edgeIDs(path), []EdgeID{"ac", "cd"}) || !arrival.Equal(hour(4)) {
+		t.Fatalf("TemporalPath() expected [ac cd] arriving at 4h, got: %v at %v, %v", edgeIDs(path), arrival, err)
+	}
+	if _, _, err := TemporalPath(g, "a", "d", hour(4), halfHour); !errors.Is(err, ErrNoPath) {
+		t.Fatalf("TemporalPath() expected error ErrNoPath, got: %v", err)
+	}
+	arrivals := TemporalArrivals(g, "a", hour(0), nil)
+	if !arrivals["b"].Equal(hour(1)) || !arrivals["d"].Equal(hour(3)) {
+		t.Fatalf("TemporalArrivals() expected b at 1h and d at 3h, got: %v", arrivals)
+	}
+	_, _ = g.AddVertex("")
+	_, _ = g.AddEdge("a_", "a", "", WithValidity(hour(0), hour(1)))
+	_, _ = g.AddEdge("_c", "", "c", WithValidity(hour(1), hour(2)))
+	if arrivals := TemporalArrivals(g, "a", hour(0), nil); !arrivals["c"].Equal(hour(1)) {
+		t.Fatalf("TemporalArrivals() expected the search to go through vertex '', got: %v", arrivals)
+	}
+}
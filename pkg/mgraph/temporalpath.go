@@ -0,0 +1,106 @@
+package mgraph
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+)
+
+// TraversalTime returns how long traversing an edge takes. A nil TraversalTime
+// traverses every edge instantly.
+type TraversalTime[V, E any] func(e TypedEdge[V, E]) time.Duration
+
+func (d TraversalTime[V, E]) duration(e TypedEdge[V, E]) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d(e)
+}
+
+// TemporalPath returns the path from a vertex to another that arrives the earliest
+// when leaving at start, along with its arrival time. The path is time-respecting:
+// every edge is taken at a time it holds, no earlier than the arrival at its tail,
+// waiting at a vertex as long as it holds. Vertices must hold when they are reached.
+func TemporalPath[V, E any](g TypedView[V, E], from, to VertexID, start time.Time, duration TraversalTime[V, E]) ([]TypedEdge[V, E], time.Time, error) {
+	if err := checkEndpoints(g, from, to); err != nil {
+		return nil, time.Time{}, err
+	}
+	arrivals, prev := temporalSearch(g, from, start, duration, &to)
+	arrival, ok := arrivals[to]
+	if !ok {
+		return nil, time.Time{}, fmt.Errorf("error while searching path from '%s' to '%s': %w", from, to, ErrNoPath)
+	}
+	return buildPath(prev, from, to), arrival, nil
+}
+
+// TemporalArrivals returns the earliest arrival time at every vertex reachable
+// from a vertex when leaving it at start, following time-respecting paths.
+func TemporalArrivals[V, E any](g TypedView[V, E], from VertexID, start time.Time, duration TraversalTime[V, E]) map[VertexID]time.Time {
+	arrivals, _ := temporalSearch(g, from, start, duration, nil)
+	return arrivals
+}
+
+// temporalSearch runs Dijkstra on arrival times, stopping once the target is
+// reached. A nil target searches every reachable vertex.
+func temporalSearch[V, E any](g TypedView[V, E], from VertexID, start time.Time, duration TraversalTime[V, E], to *VertexID) (map[VertexID]time.Time, map[VertexID]TypedEdge[V, E]) {
+	arrivals := map[VertexID]time.Time{}
+	prev := map[VertexID]TypedEdge[V, E]{}
+	if v := g.Vertex(from); v == nil || !v.Validity().Contains(start) {
+		return arrivals, prev
+	}
+	arrivals[from] = start
+	queue := &arrivalQueue{{id: from, arrival: start}}
+	for queue.Len() > 0 {
+		it := heap.Pop(queue).(arrivalItem)
+		if to != nil && it.id == *to {
+			break
+		}
+		if it.arrival.After(arrivals[it.id]) {
+			continue
+		}
+		v := g.Vertex(it.id)
+		if v == nil {
+			continue
+		}
+		for _, e := range v.Outgoing() {
+			departure := it.arrival
+			if validity := e.Validity(); validity.From.After(departure) {
+				departure = validity.From
+			}
+			if !e.Validity().Contains(departure) || !v.Validity().Contains(departure) {
+				continue
+			}
+			next := opposite(e, it.id)
+			arrival := departure.Add(duration.duration(e))
+			if old, ok := arrivals[next]; ok && !arrival.Before(old) {
+				continue
+			}
+			if w := g.Vertex(next); w == nil || !w.Validity().Contains(arrival) {
+				continue
+			}
+			arrivals[next] = arrival
+			prev[next] = e
+			heap.Push(queue, arrivalItem{id: next, arrival: arrival})
+		}
+	}
+	return arrivals, prev
+}
+
+type arrivalItem struct {
+	id      VertexID
+	arrival time.Time
+}
+
+type arrivalQueue []arrivalItem
+
+func (q arrivalQueue) Len() int           { return len(q) }
+func (q arrivalQueue) Less(i, j int) bool { return q[i].arrival.Before(q[j].arrival) }
+func (q arrivalQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *arrivalQueue) Push(x any)        { *q = append(*q, x.(arrivalItem)) }
+
+func (q *arrivalQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,111 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrCycleDetected = errors.New("cycle detected")
+
+type CycleError struct {
+	Cycle []EdgeID
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrCycleDetected, e.Cycle)
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycleDetected
+}
+
+// TopologicalSort uses Kahn's algorithm. When the graph is not acyclic the returned
+// error is a *CycleError holding one of its cycles. Undirected edges impose no
+// order and are ignored, here as well as by FindCycle.
+func TopologicalSort[V, E any](g TypedView[V, E]) ([]VertexID, error) {
+	inDegree := make(map[VertexID]int, g.Order())
+	var ready []VertexID
+	g.ForEachVertex(func(v TypedVertex[V, E]) bool {
+		for _, e := range v.Incoming() {
+			if e.IsDirected() && g.Vertex(e.From()) != nil {
+				inDegree[v.Id()]++
+			}
+		}
+		if inDegree[v.Id()] == 0 {
+			ready = append(ready, v.Id())
+		}
+		return true
+	})
+	sorted := make([]VertexID, 0, g.Order())
+	for len(ready) > 0 {
+		id := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		sorted = append(sorted, id)
+		for _, e := range g.Vertex(id).Outgoing() {
+			if !e.IsDirected() || g.Vertex(e.To()) == nil {
+				continue
+			}
+			inDegree[e.To()]--
+			if inDegree[e.To()] == 0 {
+				ready = append(ready, e.To())
+			}
+		}
+	}
+	if len(sorted) < g.Order() {
+		cycle, _ := FindCycle(g)
+		return nil, fmt.Errorf("error while sorting graph: %w", &CycleError{Cycle: cycle})
+	}
+	return sorted, nil
+}
+
+// FindCycle returns the edges of a directed cycle, in path order, if there is any.
+// Self-loops are cycles of a single edge.
+func FindCycle[V, E any](g TypedView[V, E]) ([]EdgeID, bool) {
+	type frame struct {
+		id    VertexID
+		edges []TypedEdge[V, E]
+	}
+	colors := make(map[VertexID]color, g.Order())
+	parent := map[VertexID]TypedEdge[V, E]{}
+	var cycle []EdgeID
+	g.ForEachVertex(func(root TypedVertex[V, E]) bool {
+		if colors[root.Id()] != white {
+			return true
+		}
+		colors[root.Id()] = gray
+		frames := []*frame{{id: root.Id(), edges: root.Outgoing()}}
+		for len(frames) > 0 {
+			f := frames[len(frames)-1]
+			if len(f.edges) == 0 {
+				colors[f.id] = black
+				frames = frames[:len(frames)-1]
+				continue
+			}
+			e := f.edges[0]
+			f.edges = f.edges[1:]
+			if !e.IsDirected() {
+				continue
+			}
+			switch colors[e.To()] {
+			case white:
+				next := g.Vertex(e.To())
+				if next == nil {
+					continue
+				}
+				colors[next.Id()] = gray
+				parent[next.Id()] = e
+				frames = append(frames, &frame{id: next.Id(), edges: next.Outgoing()})
+			case gray:
+				cycle = []EdgeID{e.Id()}
+				for at := f.id; at != e.To(); at = parent[at].From() {
+					cycle = append(cycle, parent[at].Id())
+				}
+				slices.Reverse(cycle)
+				return false
+			}
+		}
+		return true
+	})
+	return cycle, cycle != nil
+}
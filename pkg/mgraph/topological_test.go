@@ -0,0 +1,70 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func newHierarchyGraph() Graph {
+	g := New()
+	for _, id := range []VertexID{"series", "season1", "season2", "chapter1", "chapter2", "chapter3"} {
+		_, _ = g.AddVertex(id)
+	}
+	_, _ = g.AddEdge("edge1", "series", "season1")
+	_, _ = g.AddEdge("edge2", "series", "season2")
+	_, _ = g.AddEdge("edge3", "season1", "chapter1")
+	_, _ = g.AddEdge("edge4", "season1", "chapter2")
+	_, _ = g.AddEdge("edge5", "season2", "chapter3")
+	return g
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := newHierarchyGraph()
+	sorted, err := TopologicalSort(g)
+	if err != nil {
+		t.Fatalf("TopologicalSort() expected no error, got: %v", err)
+	}
+	if len(sorted) != g.Order() {
+		t.Fatalf("TopologicalSort() expected %d vertices, got: %v", g.Order(), sorted)
+	}
+	for _, e := range g.Edges() {
+		if slices.Index(sorted, e.From()) > slices.Index(sorted, e.To()) {
+			t.Fatalf("TopologicalSort() expected %s before %s, got: %v", e.From(), e.To(), sorted)
+		}
+	}
+}
+
+func TestTopologicalSort_ErrorCycleDetected(t *testing.T) {
+	g := newHierarchyGraph()
+	_, _ = g.AddEdge("edge6", "chapter2", "series")
+	_, err := TopologicalSort(g)
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("TopologicalSort() expected error ErrCycleDetected, got: %v", err)
+	}
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("TopologicalSort() expected a CycleError, got: %v", err)
+	}
+	if !slices.Equal(cycleErr.Cycle, []EdgeID{"edge1", "edge4", "edge6"}) &&
+		!slices.Equal(cycleErr.Cycle, []EdgeID{"edge4", "edge6", "edge1"}) &&
+		!slices.Equal(cycleErr.Cycle, []EdgeID{"edge6", "edge1", "edge4"}) {
+		t.Fatalf("TopologicalSort() expected cycle [edge1 edge4 edge6], got: %v", cycleErr.Cycle)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	g := newHierarchyGraph()
+	if cycle, ok := FindCycle(g); ok {
+		t.Fatalf("FindCycle() expected no cycle, got: %v", cycle)
+	}
+	_, _ = g.AddEdge("edge6", "chapter3", "chapter3")
+	cycle, ok := FindCycle(g)
+	if !ok || !slices.Equal(cycle, []EdgeID{"edge6"}) {
+		t.Fatalf("FindCycle() expected cycle [edge6], got: %v", cycle)
+	}
+	_, err := TopologicalSort(g)
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("TopologicalSort() expected error ErrCycleDetected, got: %v", err)
+	}
+}
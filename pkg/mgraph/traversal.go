@@ -0,0 +1,182 @@
+package mgraph
+
+import "fmt"
+
+type Direction int
+
+const (
+	FollowOutgoing Direction = iota
+	FollowIncoming
+	FollowBoth
+)
+
+type TraversalOptions struct {
+	Direction Direction
+	// MaxDepth limits how far from the start vertex the traversal goes; zero means no limit.
+	MaxDepth int
+}
+
+// Visitor hooks are all optional. Returning false from any of them stops the traversal.
+// BFS reports every non-tree edge reaching an already discovered vertex as a back edge,
+// while DFS only reports edges closing a cycle with the current path.
+type Visitor[V, E any] struct {
+	DiscoverVertex func(v TypedVertex[V, E], depth int) bool
+	FinishVertex   func(v TypedVertex[V, E]) bool
+	TreeEdge       func(e TypedEdge[V, E]) bool
+	BackEdge       func(e TypedEdge[V, E]) bool
+}
+
+type color int
+
+const (
+	white color = iota
+	gray
+	black
+)
+
+func BFS[V, E any](g TypedView[V, E], start VertexID, visitor Visitor[V, E], opts TraversalOptions) error {
+	s := g.Vertex(start)
+	if s == nil {
+		return fmt.Errorf("error while traversing from '%s': %w", start, ErrVertexDoesNotExists)
+	}
+	type item struct {
+		v     TypedVertex[V, E]
+		depth int
+		via   EdgeID
+	}
+	colors := map[VertexID]color{start: gray}
+	if !visitor.discover(s, 0) {
+		return nil
+	}
+	reported := map[EdgeID]bool{}
+	queue := []item{{v: s}}
+	for len(queue) > 0 {
+		it := queue[0]
+		queue = queue[1:]
+		for _, e := range expand(it.v, opts.Direction, it.depth, opts.MaxDepth) {
+			if e.Id() == it.via && (!e.IsDirected() || opts.Direction == FollowBoth) || reported[e.Id()] {
+				continue
+			}
+			next := g.Vertex(opposite(e, it.v.Id()))
+			if next == nil {
+				continue
+			}
+			switch colors[next.Id()] {
+			case white:
+				colors[next.Id()] = gray
+				reported[e.Id()] = true
+				if !visitor.tree(e) || !visitor.discover(next, it.depth+1) {
+					return nil
+				}
+				queue = append(queue, item{v: next, depth: it.depth + 1, via: e.Id()})
+			default:
+				reported[e.Id()] = true
+				if !visitor.back(e) {
+					return nil
+				}
+			}
+		}
+		colors[it.v.Id()] = black
+		if !visitor.finish(it.v) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func DFS[V, E any](g TypedView[V, E], start VertexID, visitor Visitor[V, E], opts TraversalOptions) error {
+	s := g.Vertex(start)
+	if s == nil {
+		return fmt.Errorf("error while traversing from '%s': %w", start, ErrVertexDoesNotExists)
+	}
+	type frame struct {
+		v     TypedVertex[V, E]
+		edges []TypedEdge[V, E]
+		depth int
+		via   EdgeID
+	}
+	colors := map[VertexID]color{start: gray}
+	if !visitor.discover(s, 0) {
+		return nil
+	}
+	stack := []*frame{{v: s, edges: expand(s, opts.Direction, 0, opts.MaxDepth)}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		if len(f.edges) == 0 {
+			stack = stack[:len(stack)-1]
+			colors[f.v.Id()] = black
+			if !visitor.finish(f.v) {
+				return nil
+			}
+			continue
+		}
+		e := f.edges[0]
+		f.edges = f.edges[1:]
+		if e.Id() == f.via && (!e.IsDirected() || opts.Direction == FollowBoth) {
+			continue
+		}
+		next := g.Vertex(opposite(e, f.v.Id()))
+		if next == nil {
+			continue
+		}
+		switch colors[next.Id()] {
+		case white:
+			colors[next.Id()] = gray
+			if !visitor.tree(e) || !visitor.discover(next, f.depth+1) {
+				return nil
+			}
+			stack = append(stack, &frame{
+				v:     next,
+				edges: expand(next, opts.Direction, f.depth+1, opts.MaxDepth),
+				depth: f.depth + 1,
+				via:   e.Id(),
+			})
+		case gray:
+			if !visitor.back(e) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func adjacent[V, E any](v TypedVertex[V, E], d Direction) []TypedEdge[V, E] {
+	switch d {
+	case FollowIncoming:
+		return v.Incoming()
+	case FollowBoth:
+		return v.Edges()
+	default:
+		return v.Outgoing()
+	}
+}
+
+func expand[V, E any](v TypedVertex[V, E], d Direction, depth, maxDepth int) []TypedEdge[V, E] {
+	if maxDepth > 0 && depth >= maxDepth {
+		return nil
+	}
+	return adjacent(v, d)
+}
+
+func opposite[V, E any](e TypedEdge[V, E], id VertexID) VertexID {
+	if e.From() == id {
+		return e.To()
+	}
+	return e.From()
+}
+
+func (vis Visitor[V, E]) discover(v TypedVertex[V, E], depth int) bool {
+	return vis.DiscoverVertex == nil || vis.DiscoverVertex(v, depth)
+}
+
+func (vis Visitor[V, E]) finish(v TypedVertex[V, E]) bool {
+	return vis.FinishVertex == nil || vis.FinishVertex(v)
+}
+
+func (vis Visitor[V, E]) tree(e TypedEdge[V, E]) bool {
+	return vis.TreeEdge == nil || vis.TreeEdge(e)
+}
+
+func (vis Visitor[V, E]) back(e TypedEdge[V, E]) bool {
+	return vis.BackEdge == nil || vis.BackEdge(e)
+}
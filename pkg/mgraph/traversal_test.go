@@ -0,0 +1,243 @@
+package mgraph
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func newTraversalGraph() Graph {
+	g := New()
+	_, _ = g.AddVertex("node1")
+	_, _ = g.AddVertex("node2")
+	_, _ = g.AddVertex("node3")
+	_, _ = g.AddVertex("node4")
+	_, _ = g.AddVertex("node5")
+	_, _ = g.AddEdge("edge1", "node1", "node2")
+	_, _ = g.AddEdge("edge2", "node2", "node3")
+	_, _ = g.AddEdge("edge3", "node3", "node1")
+	_, _ = g.AddEdge("edge4", "node3", "node4")
+	_, _ = g.AddEdge("edge5", "node5", "node4")
+	return g
+}
+
+func TestBFS(t *testing.T) {
+	g := newTraversalGraph()
+	depths := map[VertexID]int{}
+	var tree, back []EdgeID
+	err := BFS(g, "node1", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			depths[v.Id()] = depth
+			return true
+		},
+		TreeEdge: func(e Edge) bool {
+			tree = append(tree, e.Id())
+			return true
+		},
+		BackEdge: func(e Edge) bool {
+			back = append(back, e.Id())
+			return true
+		},
+	}, TraversalOptions{})
+	if err != nil {
+		t.Fatalf("BFS() expected no error, got: %v", err)
+	}
+	expected := map[VertexID]int{"node1": 0, "node2": 1, "node3": 2, "node4": 3}
+	if len(depths) != len(expected) {
+		t.Fatalf("BFS() expected to discover %v, got: %v", expected, depths)
+	}
+	for id, d := range expected {
+		if depths[id] != d {
+			t.Fatalf("BFS() expected depth %d for %s, got: %d", d, id, depths[id])
+		}
+	}
+	if !slices.Equal(tree, []EdgeID{"edge1", "edge2", "edge4"}) {
+		t.Fatalf("BFS() expected tree edges [edge1 edge2 edge4], got: %v", tree)
+	}
+	if !slices.Equal(back, []EdgeID{"edge3"}) {
+		t.Fatalf("BFS() expected back edges [edge3], got: %v", back)
+	}
+}
+
+func TestBFS_ErrorVertexDoesNotExists(t *testing.T) {
+	g := newTraversalGraph()
+	err := BFS(g, "node9", Visitor[any, any]{}, TraversalOptions{})
+	if !errors.Is(err, ErrVertexDoesNotExists) {
+		t.Fatalf("BFS() expected error ErrVertexDoesNotExists, got: %v", err)
+	}
+}
+
+func TestBFS_WithMaxDepth(t *testing.T) {
+	g := newTraversalGraph()
+	visited := map[VertexID]bool{}
+	_ = BFS(g, "node1", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			visited[v.Id()] = true
+			return true
+		},
+	}, TraversalOptions{MaxDepth: 1})
+	if len(visited) != 2 || !visited["node2"] {
+		t.Fatalf("BFS() expected to visit node1 and node2, visited: %v", visited)
+	}
+}
+
+func TestBFS_FollowIncoming(t *testing.T) {
+	g := newTraversalGraph()
+	visited := map[VertexID]bool{}
+	_ = BFS(g, "node4", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			visited[v.Id()] = true
+			return true
+		},
+	}, TraversalOptions{Direction: FollowIncoming})
+	if len(visited) != 5 {
+		t.Fatalf("BFS() expected to visit 5 vertices, visited: %v", visited)
+	}
+}
+
+func TestBFS_WithBreak(t *testing.T) {
+	g := newTraversalGraph()
+	visited := map[VertexID]bool{}
+	_ = BFS(g, "node1", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			visited[v.Id()] = true
+			return len(visited) < 2
+		},
+	}, TraversalOptions{})
+	if len(visited) != 2 {
+		t.Fatalf("BFS() expected to visit 2 vertices, visited: %v", visited)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g := newTraversalGraph()
+	var discovered, finished []VertexID
+	var back []EdgeID
+	err := DFS(g, "node1", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			discovered = append(discovered, v.Id())
+			return true
+		},
+		FinishVertex: func(v Vertex) bool {
+			finished = append(finished, v.Id())
+			return true
+		},
+		BackEdge: func(e Edge) bool {
+			back = append(back, e.Id())
+			return true
+		},
+	}, TraversalOptions{})
+	if err != nil {
+		t.Fatalf("DFS() expected no error, got: %v", err)
+	}
+	if !slices.Equal(discovered[:3], []VertexID{"node1", "node2", "node3"}) || len(discovered) != 4 {
+		t.Fatalf("DFS() expected to discover node1, node2, node3 and node4, got: %v", discovered)
+	}
+	if finished[len(finished)-1] != "node1" {
+		t.Fatalf("DFS() expected to finish node1 last, got: %v", finished)
+	}
+	if !slices.Equal(back, []EdgeID{"edge3"}) {
+		t.Fatalf("DFS() expected back edges [edge3], got: %v", back)
+	}
+}
+
+func TestDFS_FollowBoth(t *testing.T) {
+	g := newTraversalGraph()
+	visited := map[VertexID]bool{}
+	var back []EdgeID
+	_ = DFS(g, "node5", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			visited[v.Id()] = true
+			return true
+		},
+		BackEdge: func(e Edge) bool {
+			back = append(back, e.Id())
+			return true
+		},
+	}, TraversalOptions{Direction: FollowBoth})
+	if len(visited) != 5 {
+		t.Fatalf("DFS() expected to visit 5 vertices, visited: %v", visited)
+	}
+	if len(back) != 1 {
+		t.Fatalf("DFS() expected 1 back edge, got: %v", back)
+	}
+}
+
+func TestDFS_WithMaxDepthAndLoop(t *testing.T) {
+	g := newTraversalGraph()
+	_, _ = g.AddEdge("edge6", "node1", "node1")
+	visited := map[VertexID]bool{}
+	var back []EdgeID
+	_ = DFS(g, "node1", Visitor[any, any]{
+		DiscoverVertex: func(v Vertex, depth int) bool {
+			visited[v.Id()] = true
+			return true
+		},
+		BackEdge: func(e Edge) bool {
+			back = append(back, e.Id())
+			return true
+		},
+	}, TraversalOptions{MaxDepth: 1})
+	if len(visited) != 2 {
+		t.Fatalf("DFS() expected to visit 2 vertices, visited: %v", visited)
+	}
+	if !slices.Equal(back, []EdgeID{"edge6"}) {
+		t.Fatalf("DFS() expected back edges [edge6], got: %v", back)
+	}
+}
+
+var traversals = map[string]func(View, VertexID, Visitor[any, any], TraversalOptions) error{"BFS": BFS[any, any], "DFS": DFS[any, any]}
+
+func collectEdges(traverse func(View, VertexID, Visitor[any, any], TraversalOptions) error, g Graph) (tree, back []EdgeID) {
+	_ = traverse(g, "a", Visitor[any, any]{
+		TreeEdge: func(e Edge) bool {
+			tree = append(tree, e.Id())
+			return true
+		},
+		BackEdge: func(e Edge) bool {
+			back = append(back, e.Id())
+			return true
+		},
+	}, TraversalOptions{})
+	return
+}
+
+func TestTraversal_Undirected(t *testing.T) {
+	g := New(WithDirectedness(Undirected))
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddVertex("c")
+	_, _ = g.AddEdge("ab", "a", "b")
+	_, _ = g.AddEdge("bc", "b", "c")
+	for name, traverse := range traversals {
+		if tree, back := collectEdges(traverse, g); !slices.Equal(tree, []EdgeID{"ab", "bc"}) || len(back) != 0 {
+			t.Fatalf("%s() expected tree edges [ab bc] and no back edges, got: %v %v", name, tree, back)
+		}
+	}
+	_, _ = g.AddEdge("ca", "c", "a")
+	for name, traverse := range traversals {
+		if _, back := collectEdges(traverse, g); len(back) != 1 {
+			t.Fatalf("%s() expected one back edge closing the cycle, got: %v", name, back)
+		}
+	}
+}
+
+func TestTraversal_Mixed(t *testing.T) {
+	g := New(WithDirectedness(Mixed))
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddVertex("c")
+	_, _ = g.AddEdge("ab", "a", "b", UndirectedEdge())
+	_, _ = g.AddEdge("bc", "b", "c")
+	for name, traverse := range traversals {
+		if tree, back := collectEdges(traverse, g); !slices.Equal(tree, []EdgeID{"ab", "bc"}) || len(back) != 0 {
+			t.Fatalf("%s() expected tree edges [ab bc] and no back edges, got: %v %v", name, tree, back)
+		}
+	}
+	_, _ = g.AddEdge("ca", "c", "a")
+	for name, traverse := range traversals {
+		if _, back := collectEdges(traverse, g); !slices.Equal(back, []EdgeID{"ca"}) {
+			t.Fatalf("%s() expected back edges [ca], got: %v", name, back)
+		}
+	}
+}
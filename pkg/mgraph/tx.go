@@ -0,0 +1,445 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+var ErrTxDone = errors.New("transaction already committed or rolled back")
+
+type Tx = TypedTx[any, any]
+
+// TypedTx is a transaction over a graph. Its mutations, as well as those made
+// through its vertices and edges, are applied to the graph right away, so reads
+// see them, while their inverses are journaled so that Rollback can restore the
+// graph exactly as it was at Begin: elements, adjacency, enumeration order, data,
+// properties, indexes, schema, consistency and acyclicity. Once it ends, its
+// mutators, and those of its vertices and edges, fail with ErrTxDone, or panic
+// with it when they cannot return an error.
+//
+// Transactions nest: Begin on a transaction opens an inner one whose Commit only
+// hands its changes over to the outer one. Ending a transaction also ends the
+// inner ones still open. On concurrent graphs, the other mutations, through the
+// graph or through vertices and edges not obtained from the transaction, wait
+// until it ends, so the goroutine owning it, or a subscriber it notifies, must
+// only mutate the graph through it. Other goroutines still see its changes as
+// they are made. On other graphs, mutations made outside the transaction while
+// it is open are part of it.
+type TypedTx[V, E any] interface {
+	TypedGraph[V, E]
+	Commit() error
+	Rollback() error
+}
+
+type tx[V, E any] struct {
+	*graph[V, E]
+	start        int
+	historyStart int
+	done         bool
+}
+
+// lock takes the write lock of a graph for a mutation by the transaction t, which
+// must still be open, or by the graph itself when t is nil, which then waits until
+// no transaction is open. Unlocking calls commit, so that the mutation becomes a
+// new version unless a transaction is open.
+func lock[V, E any](mu, txMu locker, t *tx[V, E], commit func()) (unlock func(), err error) {
+	if t == nil {
+		txMu.Lock()
+		mu.Lock()
+		return func() {
+			commit()
+			mu.Unlock()
+			txMu.Unlock()
+		}, nil
+	}
+	mu.Lock()
+	if t.done {
+		mu.Unlock()
+		return nil, ErrTxDone
+	}
+	return func() {
+		commit()
+		mu.Unlock()
+	}, nil
+}
+
+func (g *graph[V, E]) lock(t *tx[V, E]) (unlock func(), err error) {
+	return lock(g.mu, g.txMu, t, g.commit)
+}
+
+func (g *graph[V, E]) Begin() TypedTx[V, E] {
+	return g.beginBy(nil)
+}
+
+// beginBy opens a transaction, inside the outer one when it is not nil.
+func (g *graph[V, E]) beginBy(outer *tx[V, E]) TypedTx[V, E] {
+	if outer == nil {
+		g.txMu.Lock()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if outer != nil && outer.done {
+		panic(fmt.Errorf("error while beginning transaction: %w", ErrTxDone))
+	}
+	g.seal()
+	t := &tx[V, E]{graph: g, start: len(g.journal)}
+	if g.history != nil {
+		t.historyStart = len(g.history.current)
+	}
+	g.txs = append(g.txs, t)
+	return t
+}
+
+func (t *tx[V, E]) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return fmt.Errorf("error while committing transaction: %w", ErrTxDone)
+	}
+	t.end()
+	t.seal()
+	return nil
+}
+
+func (t *tx[V, E]) Rollback() error {
+	defer t.hub.publish()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seal()
+	if t.done {
+		return fmt.Errorf("error while rolling back transaction: %w", ErrTxDone)
+	}
+	t.undoing = true
+	for i := len(t.journal) - 1; i >= t.start; i-- {
+		t.journal[i]()
+	}
+	t.undoing = false
+	clear(t.journal[t.start:])
+	t.journal = t.journal[:t.start]
+	if h := t.graph.history; h != nil {
+		start := min(t.historyStart, len(h.current))
+		clear(h.current[start:])
+		h.current = h.current[:start]
+	}
+	t.end()
+	t.discard()
+	return nil
+}
+
+// end closes the transaction and the inner ones, dropping the journal and letting
+// other mutations in once no transaction is left open.
+func (t *tx[V, E]) end() {
+	for len(t.txs) > 0 {
+		inner := t.txs[len(t.txs)-1]
+		t.txs = t.txs[:len(t.txs)-1]
+		inner.done = true
+		if inner == t {
+			break
+		}
+	}
+	if len(t.txs) == 0 {
+		t.journal = nil
+		t.txMu.Unlock()
+	}
+}
+
+// record journals the inverse of a mutation while a transaction is open, and
+// in the history of the graph when it is enabled. The inverses run by Rollback
+// are not recorded: the mutations they revert are dropped from the history.
+func (g *graph[V, E]) record(undo func()) {
+	if g.undoing {
+		return
+	}
+	if len(g.txs) > 0 {
+		g.journal = append(g.journal, undo)
+	}
+	if g.history != nil {
+		g.history.current = append(g.history.current, undo)
+	}
+}
+
+// txVertex and txEdge are the vertices and edges of a transaction: they mutate
+// the graph on its behalf.
+type txVertex[V, E any] struct {
+	*vertex[V, E]
+	tx *tx[V, E]
+}
+
+type txEdge[V, E any] struct {
+	*edge[V, E]
+	tx *tx[V, E]
+}
+
+// wrapVertex returns the vertex of the transaction, or v itself when t is nil.
+func (t *tx[V, E]) wrapVertex(v TypedVertex[V, E]) TypedVertex[V, E] {
+	if t == nil || v == nil {
+		return v
+	}
+	return &txVertex[V, E]{vertex: v.(*vertex[V, E]), tx: t}
+}
+
+// wrapEdge returns the edge of the transaction, or e itself when t is nil.
+func (t *tx[V, E]) wrapEdge(e TypedEdge[V, E]) TypedEdge[V, E] {
+	if t == nil || e == nil {
+		return e
+	}
+	return &txEdge[V, E]{edge: e.(*edge[V, E]), tx: t}
+}
+
+func (t *tx[V, E]) AddVertex(id VertexID, opts ...ElementOption) (TypedVertex[V, E], error) {
+	return t.graph.addVertexBy(t, id, opts...)
+}
+
+func (t *tx[V, E]) Vertex(id VertexID) TypedVertex[V, E] {
+	return t.wrapVertex(t.graph.Vertex(id))
+}
+
+func (t *tx[V, E]) RemoveVertex(id VertexID) {
+	t.graph.removeVertexBy(t, id)
+}
+
+func (t *tx[V, E]) Vertices() []TypedVertex[V, E] {
+	return wrapVertices(t.graph.Vertices(), t.wrapVertex)
+}
+
+func (t *tx[V, E]) ForEachVertex(each func(v TypedVertex[V, E]) bool) {
+	t.graph.ForEachVertex(func(v TypedVertex[V, E]) bool {
+		return each(t.wrapVertex(v))
+	})
+}
+
+func (t *tx[V, E]) AllVertices() iter.Seq[TypedVertex[V, E]] {
+	return values(t.VertexEntries())
+}
+
+func (t *tx[V, E]) VertexEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return wrapVertexEntries(t.graph.VertexEntries(), t.wrapVertex)
+}
+
+func (t *tx[V, E]) AddEdge(id EdgeID, from, to VertexID, opts ...ElementOption) (TypedEdge[V, E], error) {
+	return t.graph.addEdgeBy(t, id, from, to, opts...)
+}
+
+func (t *tx[V, E]) Edge(id EdgeID) TypedEdge[V, E] {
+	return t.wrapEdge(t.graph.Edge(id))
+}
+
+func (t *tx[V, E]) RemoveEdge(id EdgeID) {
+	t.graph.removeEdgeBy(t, id)
+}
+
+func (t *tx[V, E]) Edges() []TypedEdge[V, E] {
+	return wrapEdges(t.graph.Edges(), t.wrapEdge)
+}
+
+func (t *tx[V, E]) ForEachEdge(each func(e TypedEdge[V, E]) bool) {
+	t.graph.ForEachEdge(func(e TypedEdge[V, E]) bool {
+		return each(t.wrapEdge(e))
+	})
+}
+
+func (t *tx[V, E]) AllEdges() iter.Seq[TypedEdge[V, E]] {
+	return values(t.EdgeEntries())
+}
+
+func (t *tx[V, E]) EdgeEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return wrapEdgeEntries(t.graph.EdgeEntries(), t.wrapEdge)
+}
+
+func (t *tx[V, E]) EdgesBetween(from, to VertexID) []TypedEdge[V, E] {
+	return wrapEdges(t.graph.EdgesBetween(from, to), t.wrapEdge)
+}
+
+func (t *tx[V, E]) VerticesByLabel(label string) []TypedVertex[V, E] {
+	return wrapVertices(t.graph.VerticesByLabel(label), t.wrapVertex)
+}
+
+func (t *tx[V, E]) EdgesByLabel(label string) []TypedEdge[V, E] {
+	return wrapEdges(t.graph.EdgesByLabel(label), t.wrapEdge)
+}
+
+func (t *tx[V, E]) EdgesBetweenByLabel(from, to VertexID, label string) []TypedEdge[V, E] {
+	return wrapEdges(t.graph.EdgesBetweenByLabel(from, to, label), t.wrapEdge)
+}
+
+func (t *tx[V, E]) CreateVertexIndex(property string, kind IndexKind) error {
+	return t.graph.createVertexIndexBy(t, property, kind)
+}
+
+func (t *tx[V, E]) CreateEdgeIndex(property string, kind IndexKind) error {
+	return t.graph.createEdgeIndexBy(t, property, kind)
+}
+
+func (t *tx[V, E]) DropVertexIndex(property string) {
+	t.graph.dropVertexIndexBy(t, property)
+}
+
+func (t *tx[V, E]) DropEdgeIndex(property string) {
+	t.graph.dropEdgeIndexBy(t, property)
+}
+
+func (t *tx[V, E]) VerticesByProperty(property string, value any) []TypedVertex[V, E] {
+	return wrapVertices(t.graph.VerticesByProperty(property, value), t.wrapVertex)
+}
+
+func (t *tx[V, E]) EdgesByProperty(property string, value any) []TypedEdge[V, E] {
+	return wrapEdges(t.graph.EdgesByProperty(property, value), t.wrapEdge)
+}
+
+func (t *tx[V, E]) VerticesByPropertyRange(property string, from, to any) ([]TypedVertex[V, E], error) {
+	vertices, err := t.graph.VerticesByPropertyRange(property, from, to)
+	return wrapVertices(vertices, t.wrapVertex), err
+}
+
+func (t *tx[V, E]) EdgesByPropertyRange(property string, from, to any) ([]TypedEdge[V, E], error) {
+	edges, err := t.graph.EdgesByPropertyRange(property, from, to)
+	return wrapEdges(edges, t.wrapEdge), err
+}
+
+func (t *tx[V, E]) EnsureConsistency(enable bool) {
+	t.graph.ensureConsistencyBy(t, enable)
+}
+
+func (t *tx[V, E]) EnsureAcyclicity(enable bool) error {
+	return t.graph.ensureAcyclicityBy(t, enable)
+}
+
+func (t *tx[V, E]) EnforceSchema(schema *Schema) {
+	t.graph.enforceSchemaBy(t, schema)
+}
+
+func (t *tx[V, E]) Begin() TypedTx[V, E] {
+	return t.graph.beginBy(t)
+}
+
+func (t *tx[V, E]) EnableHistory(limit int) {
+	t.graph.enableHistoryBy(t, limit)
+}
+
+func (t *tx[V, E]) Undo() error {
+	return t.graph.undoBy(t)
+}
+
+func (t *tx[V, E]) Redo() error {
+	return t.graph.redoBy(t)
+}
+
+func (t *tx[V, E]) Checkpoint(name string) error {
+	return t.graph.checkpointBy(t, name)
+}
+
+func (t *tx[V, E]) RestoreCheckpoint(name string) error {
+	return t.graph.restoreCheckpointBy(t, name)
+}
+
+func (t *tx[V, E]) AsOf(at time.Time) TypedView[V, E] {
+	return asOf[V, E](t, t.properties, at)
+}
+
+func (t *tx[V, E]) Between(from, to time.Time) TypedView[V, E] {
+	return between[V, E](t, t.properties, from, to)
+}
+
+func (v *txVertex[V, E]) SetProperty(property string, value any) error {
+	return v.setPropertyBy(v.tx, property, value)
+}
+
+func (v *txVertex[V, E]) RemoveProperty(property string) error {
+	return v.removePropertyBy(v.tx, property)
+}
+
+func (v *txVertex[V, E]) SetValidity(from, to time.Time) error {
+	return v.setValidityBy(v.tx, from, to)
+}
+
+func (v *txVertex[V, E]) StoreData(data V) {
+	v.storeDataBy(v.tx, data)
+}
+
+func (v *txVertex[V, E]) Incoming() []TypedEdge[V, E] {
+	return wrapEdges(v.vertex.Incoming(), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) Outgoing() []TypedEdge[V, E] {
+	return wrapEdges(v.vertex.Outgoing(), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) Edges() []TypedEdge[V, E] {
+	return wrapEdges(v.vertex.Edges(), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) IncomingByLabel(label string) []TypedEdge[V, E] {
+	return wrapEdges(v.vertex.IncomingByLabel(label), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) OutgoingByLabel(label string) []TypedEdge[V, E] {
+	return wrapEdges(v.vertex.OutgoingByLabel(label), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) In() iter.Seq[TypedEdge[V, E]] {
+	return values(v.InEntries())
+}
+
+func (v *txVertex[V, E]) InEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return wrapEdgeEntries(v.vertex.InEntries(), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) Out() iter.Seq[TypedEdge[V, E]] {
+	return values(v.OutEntries())
+}
+
+func (v *txVertex[V, E]) OutEntries() iter.Seq2[EdgeID, TypedEdge[V, E]] {
+	return wrapEdgeEntries(v.vertex.OutEntries(), v.tx.wrapEdge)
+}
+
+func (v *txVertex[V, E]) Neighbors() iter.Seq[TypedVertex[V, E]] {
+	return values(v.NeighborEntries())
+}
+
+func (v *txVertex[V, E]) NeighborEntries() iter.Seq2[VertexID, TypedVertex[V, E]] {
+	return wrapVertexEntries(v.vertex.NeighborEntries(), v.tx.wrapVertex)
+}
+
+func (v *txVertex[V, E]) Graph() TypedGraph[V, E] {
+	if v.vertex.Graph() == nil {
+		return nil
+	}
+	return v.tx
+}
+
+func (e *txEdge[V, E]) SetProperty(property string, value any) error {
+	return e.setPropertyBy(e.tx, property, value)
+}
+
+func (e *txEdge[V, E]) RemoveProperty(property string) error {
+	return e.removePropertyBy(e.tx, property)
+}
+
+func (e *txEdge[V, E]) SetValidity(from, to time.Time) error {
+	return e.setValidityBy(e.tx, from, to)
+}
+
+func (e *txEdge[V, E]) StoreData(data E) {
+	e.storeDataBy(e.tx, data)
+}
+
+func (e *txEdge[V, E]) Tail() TypedVertex[V, E] {
+	return e.tx.wrapVertex(e.edge.Tail())
+}
+
+func (e *txEdge[V, E]) Head() TypedVertex[V, E] {
+	return e.tx.wrapVertex(e.edge.Head())
+}
+
+func (e *txEdge[V, E]) Endpoints() [2]TypedVertex[V, E] {
+	return [2]TypedVertex[V, E]{e.Tail(), e.Head()}
+}
+
+func (e *txEdge[V, E]) Graph() TypedGraph[V, E] {
+	if e.edge.Graph() == nil {
+		return nil
+	}
+	return e.tx
+}
@@ -0,0 +1,212 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTxGraph() Graph {
+	g := New(WithOrdering(InsertionOrder))
+	for _, id := range []VertexID{"a", "b", "c", "d"} {
+		v, _ := g.AddVertex(id, WithLabel("node"), WithProperty("name", string(id)))
+		v.StoreData(strings.ToUpper(string(id)))
+	}
+	for _, e := range [][3]string{{"e1", "a", "b"}, {"e2", "b", "c"}, {"e3", "a", "c"}, {"e4", "c", "d"}, {"e5", "d", "a"}} {
+		edge, _ := g.AddEdge(EdgeID(e[0]), VertexID(e[1]), VertexID(e[2]), WithLabel("link"), WithProperty("weight", len(e[0])))
+		edge.StoreData(e[0])
+	}
+	_ = g.CreateVertexIndex("name", HashIndex)
+	_ = g.CreateEdgeIndex("weight", OrderedIndex)
+	return g
+}
+
+// dumpGraph describes everything a rollback must restore, in enumeration order.
+func dumpGraph(g View) string {
+	var b strings.Builder
+	for v := range g.AllVertices() {
+		fmt.Fprintf(&b, "%s[%s %v %v] out%v in%v\n", v.Id(), v.Label(), v.Data(), v.Properties(), edgeIDs(v.Outgoing()), edgeIDs(v.Incoming()))
+	}
+	for e := range g.AllEdges() {
+		fmt.Fprintf(&b, "%s[%s %s->%s %v %v]\n", e.Id(), e.Label(), e.From(), e.To(), e.Data(), e.Properties())
+	}
+	edges, _ := g.EdgesByPropertyRange("weight", nil, nil)
+	fmt.Fprintf(&b, "labels%v%v byName%v byWeight%v consistency %v %v acyclicity %v\n",
+		vertexIDs(g.VerticesByLabel("node")), edgeIDs(g.EdgesByLabel("link")), vertexIDs(g.VerticesByProperty("name", "b")),
+		edgeIDs(edges), g.EnsuresConsistency(), g.IsConsistent(), g.EnsuresAcyclicity())
+	return b.String()
+}
+
+func TestTx_Rollback(t *testing.T) {
+	g := newTxGraph()
+	before := dumpGraph(g)
+	tx := g.Begin()
+	tx.RemoveVertex("b")
+	tx.Vertex("a").StoreData("changed")
+	_ = tx.Edge("e3").SetProperty("weight", 10)
+	_ = tx.Vertex("c").RemoveProperty("name")
+	tx.RemoveEdge("e4")
+	_, _ = tx.AddVertex("b", WithProperty("name", "b"))
+	_, _ = tx.AddEdge("e1", "b", "a")
+	tx.EnsureConsistency(false)
+	tx.RemoveVertex("d")
+	tx.DropEdgeIndex("weight")
+	_ = tx.CreateEdgeIndex("weight", HashIndex)
+	if v := tx.Vertex("b"); v == nil || v.Label() != "" {
+		t.Fatalf("Vertex() expected the transaction to see the new vertex b")
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() expected no error, got: %v", err)
+	}
+	if after := dumpGraph(g); after != before {
+		t.Fatalf("Rollback() expected graph to be restored to\n%s, got:\n%s", before, after)
+	}
+	if err := tx.Rollback(); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("Rollback() expected error ErrTxDone, got: %v", err)
+	}
+}
+
+func TestTx_Rollback_RestoresAcyclicity(t *testing.T) {
+	g := New()
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	_, _ = g.AddEdge("ab", "a", "b")
+	_ = g.EnsureAcyclicity(true)
+	tx := g.Begin()
+	tx.RemoveEdge("ab")
+	if _, err := tx.AddEdge("ba", "b", "a"); err != nil {
+		t.Fatalf("AddEdge() expected no error, got: %v", err)
+	}
+	_ = tx.EnsureAcyclicity(false)
+	_, _ = tx.AddEdge("ab2", "a", "b")
+	_ = tx.Rollback()
+	if !g.EnsuresAcyclicity() || g.Edge("ab") == nil || g.Edge("ba") != nil || g.Edge("ab2") != nil {
+		t.Fatalf("Rollback() expected acyclic graph with edge ab only, got: %v", edgeIDs(g.Edges()))
+	}
+	if _, err := g.AddEdge("ba", "b", "a"); !errors.Is(err, ErrWouldCreateCycle) {
+		t.Fatalf("AddEdge() expected error ErrWouldCreateCycle after rollback, got: %v", err)
+	}
+}
+
+func TestTx_Commit(t *testing.T) {
+	g := newTxGraph()
+	tx := g.Begin()
+	tx.RemoveVertex("a")
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit() expected no error, got: %v", err)
+	}
+	if g.Vertex("a") != nil || g.Size() != 2 {
+		t.Fatalf("Commit() expected vertex a and its edges to stay removed, got: %d edges", g.Size())
+	}
+	if err := tx.Commit(); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("Commit() expected error ErrTxDone, got: %v", err)
+	}
+}
+
+func TestTx_Nested(t *testing.T) {
+	g := newTxGraph()
+	before := dumpGraph(g)
+	outer := g.Begin()
+	outer.RemoveEdge("e1")
+	inner := outer.Begin()
+	inner.RemoveEdge("e2")
+	_ = inner.Rollback()
+	if g.Edge("e1") != nil || g.Edge("e2") == nil {
+		t.Fatalf("Rollback() expected inner transaction only to be undone")
+	}
+	inner = outer.Begin()
+	inner.RemoveVertex("c")
+	_ = inner.Commit()
+	_ = outer.Rollback()
+	if after := dumpGraph(g); after != before {
+		t.Fatalf("Rollback() expected committed inner changes to be undone by outer rollback, got:\n%s", after)
+	}
+}
+
+func TestTx_Concurrent(t *testing.T) {
+	g := NewConcurrent()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx := g.Begin()
+			id := VertexID(fmt.Sprint(i))
+			_, _ = tx.AddVertex(id)
+			tx.Vertex(id).StoreData(i)
+			if i%2 == 0 {
+				_ = tx.Commit()
+			} else {
+				_ = tx.Rollback()
+			}
+		}()
+	}
+	wg.Wait()
+	if g.Order() != 4 {
+		t.Fatalf("Order() expected the 4 committed vertices, got: %d", g.Order())
+	}
+}
+
+func TestTx_Concurrent_KeepsOutsideMutations(t *testing.T) {
+	g := NewConcurrent()
+	tx := g.Begin()
+	_, _ = tx.AddVertex("a")
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		_, _ = g.AddVertex("b")
+	}()
+	_ = tx.Rollback()
+	<-added
+	if g.Vertex("a") != nil || g.Vertex("b") == nil {
+		t.Fatalf("Rollback() expected to undo vertex a only, got: %v", vertexIDs(g.Vertices()))
+	}
+}
+
+func TestTx_Concurrent_Nested(t *testing.T) {
+	g := NewConcurrent()
+	outer := g.Begin()
+	inner := outer.Begin()
+	_, _ = inner.AddVertex("a")
+	_ = inner.Commit()
+	_ = outer.Rollback()
+	if _, err := g.AddVertex("b"); err != nil || g.Vertex("a") != nil {
+		t.Fatalf("Rollback() expected to undo vertex a and release the graph, got: %v", err)
+	}
+}
+
+func TestTx_Done(t *testing.T) {
+	g := newTxGraph()
+	tx := g.Begin()
+	v := tx.Vertex("a")
+	e := v.Outgoing()[0]
+	_ = tx.Commit()
+	if _, err := tx.AddVertex("x"); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("AddVertex() expected error ErrTxDone, got: %v", err)
+	}
+	if err := v.SetProperty("name", "x"); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("SetProperty() expected error ErrTxDone, got: %v", err)
+	}
+	if err := e.RemoveProperty("weight"); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("RemoveProperty() expected error ErrTxDone, got: %v", err)
+	}
+	for name, mutate := range map[string]func(){
+		"RemoveVertex": func() { tx.RemoveVertex("a") },
+		"StoreData":    func() { e.StoreData("x") },
+		"Begin":        func() { tx.Begin() },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrTxDone) {
+					t.Fatalf("%s() expected to panic with ErrTxDone, got: %v", name, err)
+				}
+			}()
+			mutate()
+		}()
+	}
+	if v, _ := g.Vertex("a").Property("name"); v != "a" || g.Order() != 4 {
+		t.Fatalf("Commit() expected the graph to be left unchanged, got: %v", v)
+	}
+}
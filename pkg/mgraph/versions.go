@@ -0,0 +1,189 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	ErrVersioningDisabled = errors.New("versioning is not enabled")
+	ErrVersionNotFound    = errors.New("version not found")
+)
+
+type Snapshot = TypedSnapshot[any, any]
+
+// TypedSnapshot is a read-only view of a version of a graph. It holds the version
+// until it is released, so that At can return it meanwhile.
+type TypedSnapshot[V, E any] interface {
+	TypedView[V, E]
+	Release()
+}
+
+// versions keeps the committed versions of a graph as persistent graphs. The
+// working version is updated along with the graph, under its lock, and committed
+// as the next version when a mutation ends outside of any transaction or when the
+// outermost transaction commits. The head is published atomically so that reading
+// versions takes no graph lock. Past versions are only kept while snapshots hold
+// them.
+type versions[V, E any] struct {
+	working persistent[V, E]
+	dirty   bool
+	head    atomic.Pointer[persistent[V, E]]
+	// mu guards the held versions.
+	mu   sync.Mutex
+	held map[uint64]*heldVersion[V, E]
+}
+
+type heldVersion[V, E any] struct {
+	version   *persistent[V, E]
+	snapshots int
+}
+
+func newVersions[V, E any](head *persistent[V, E]) *versions[V, E] {
+	vs := &versions[V, E]{working: *head, held: make(map[uint64]*heldVersion[V, E])}
+	vs.head.Store(head)
+	return vs
+}
+
+// commit turns the changes made since the last commit into a new version.
+func (g *graph[V, E]) commit() {
+	if g == nil {
+		return
+	}
+	vs := g.versions
+	if vs == nil || !vs.dirty || len(g.txs) > 0 {
+		return
+	}
+	head := vs.working
+	head.properties = frozenProperties(g.properties)
+	head.version = vs.head.Load().version + 1
+	vs.head.Store(head.sealed())
+	vs.dirty = false
+}
+
+// discard drops the changes made since the last commit, which a rolled back
+// transaction has undone.
+func (g *graph[V, E]) discard() {
+	if vs := g.versions; vs != nil && len(g.txs) == 0 {
+		vs.working = *vs.head.Load()
+		vs.dirty = false
+	}
+}
+
+func (g *graph[V, E]) mirrorVertex(v *vertex[V, E]) {
+	if vs := g.versions; vs != nil {
+		vs.working.putVertex(&vertexRecord[V]{id: v.id, label: v.label, seq: v.seq, props: copyProperties(v.props), validity: v.validity, data: v.data})
+		vs.dirty = true
+	}
+}
+
+func (g *graph[V, E]) mirrorVertexRemoval(v *vertex[V, E]) {
+	if vs := g.versions; vs != nil {
+		if record, ok := vs.working.vertices.get(v.id); ok {
+			vs.working.dropVertex(record)
+		}
+		vs.dirty = true
+	}
+}
+
+func (g *graph[V, E]) mirrorEdge(e *edge[V, E]) {
+	if vs := g.versions; vs != nil {
+		vs.working.putEdge(&edgeRecord[E]{id: e.id, label: e.label, from: e.from, to: e.to, directed: e.directed, seq: e.seq, props: copyProperties(e.props), validity: e.validity, data: e.data})
+		vs.dirty = true
+	}
+}
+
+func (g *graph[V, E]) mirrorEdgeRemoval(e *edge[V, E]) {
+	if vs := g.versions; vs != nil {
+		vs.working.dropEdge(e.id)
+		vs.dirty = true
+	}
+}
+
+// Version returns the last committed version of the graph, or 0 when versioning
+// is not enabled. Every mutation made outside of a transaction, as well as every
+// committed transaction, is a new version. It takes no graph lock.
+func (g *graph[V, E]) Version() uint64 {
+	if g.versions == nil {
+		return 0
+	}
+	return g.versions.head.Load().version
+}
+
+// At returns a read-only view of a version of the graph. Only the last committed
+// version and the versions held by snapshots not yet released are kept: others
+// are not found. It takes no graph lock, nor does reading the view.
+func (g *graph[V, E]) At(version uint64) (TypedView[V, E], error) {
+	vs := g.versions
+	if vs == nil {
+		return nil, fmt.Errorf("error while reading version %d: %w", version, ErrVersioningDisabled)
+	}
+	if head := vs.head.Load(); version == head.version {
+		return head, nil
+	}
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	if h, ok := vs.held[version]; ok {
+		return h.version, nil
+	}
+	return nil, fmt.Errorf("error while reading version %d: %w", version, ErrVersionNotFound)
+}
+
+// Snapshot returns a read-only view of the last committed version of the graph,
+// which holds the version until it is released. It takes no graph lock, nor does
+// reading the view.
+func (g *graph[V, E]) Snapshot() (TypedSnapshot[V, E], error) {
+	vs := g.versions
+	if vs == nil {
+		return nil, fmt.Errorf("error while taking snapshot: %w", ErrVersioningDisabled)
+	}
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	head := vs.head.Load()
+	h, ok := vs.held[head.version]
+	if !ok {
+		h = &heldVersion[V, E]{version: head}
+		vs.held[head.version] = h
+	}
+	h.snapshots++
+	var once sync.Once
+	return &snapshot[V, E]{persistent: head, release: func() {
+		once.Do(func() {
+			vs.mu.Lock()
+			defer vs.mu.Unlock()
+			if h.snapshots--; h.snapshots == 0 {
+				delete(vs.held, head.version)
+			}
+		})
+	}}, nil
+}
+
+type snapshot[V, E any] struct {
+	*persistent[V, E]
+	release func()
+}
+
+func (s *snapshot[V, E]) Release() {
+	s.release()
+}
+
+// Version returns the number of mutations that led to this version of the graph.
+func (p *persistent[V, E]) Version() uint64 {
+	return p.version
+}
+
+// At only returns the graph itself, at its own version; previous versions are
+// not tracked.
+func (p *persistent[V, E]) At(version uint64) (TypedView[V, E], error) {
+	if version != p.version {
+		return nil, fmt.Errorf("error while reading version %d: %w", version, ErrVersionNotFound)
+	}
+	return p, nil
+}
+
+// Snapshot returns the graph itself, which never changes.
+func (p *persistent[V, E]) Snapshot() (TypedSnapshot[V, E], error) {
+	return &snapshot[V, E]{persistent: p, release: func() {}}, nil
+}
@@ -0,0 +1,151 @@
+package mgraph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestGraph_At(t *testing.T) {
+	g := New(WithVersioning(), WithOrdering(InsertionOrder))
+	_, _ = g.AddVertex("a")
+	_, _ = g.AddVertex("b")
+	if version := g.Version(); version != 2 {
+		t.Fatalf("Version() expected 2, got: %d", version)
+	}
+	s, err := g.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot() expected no error, got: %v", err)
+	}
+	_, _ = g.AddEdge("ab", "a", "b")
+	g.Vertex("a").StoreData("data")
+	_ = g.Edge("ab").SetProperty("weight", 3)
+	if version := g.Version(); version != 5 {
+		t.Fatalf("Version() expected 5, got: %d", version)
+	}
+	old, err := g.At(2)
+	if err != nil {
+		t.Fatalf("At() expected no error, got: %v", err)
+	}
+	if old.Version() != 2 || old.Size() != 0 || old.Vertex("a").Data() != nil {
+		t.Fatalf("At() expected the snapshot without edges nor data")
+	}
+	current, _ := g.At(5)
+	if weight, _ := current.Edge("ab").Property("weight"); weight != 3 || current.Vertex("a").Data() != "data" {
+		t.Fatalf("At() expected the current version, got: weight %v", weight)
+	}
+	if _, err := g.At(3); !errors.Is(err, ErrVersionNotFound) {
+		t.Fatalf("At() expected error ErrVersionNotFound for a version nobody holds, got: %v", err)
+	}
+	s.Release()
+	s.Release()
+	if _, err := g.At(2); !errors.Is(err, ErrVersionNotFound) {
+		t.Fatalf("At() expected error ErrVersionNotFound once the snapshot is released, got: %v", err)
+	}
+	if s.Order() != 2 {
+		t.Fatalf("Release() expected the snapshot to remain readable")
+	}
+}
+
+func TestGraph_Version_Tx(t *testing.T) {
+	g := New(WithVersioning())
+	_, _ = g.AddVertex("a")
+	tx := g.Begin()
+	_, _ = tx.AddVertex("b")
+	tx.RemoveVertex("a")
+	if version, view := tx.Version(), mustAt(t, tx, 1); version != 1 || view.Vertex("a") == nil {
+		t.Fatalf("Version() expected the changes of an open transaction to be invisible")
+	}
+	_ = tx.Commit()
+	if version, view := g.Version(), mustAt(t, g, 2); version != 2 || view.Vertex("a") != nil || view.Vertex("b") == nil {
+		t.Fatalf("Version() expected the transaction to be committed as version 2, got: %d", version)
+	}
+	tx = g.Begin()
+	tx.RemoveVertex("b")
+	_ = tx.Rollback()
+	if version := g.Version(); version != 2 {
+		t.Fatalf("Version() expected a rolled back transaction to leave the version unchanged, got: %d", version)
+	}
+	_, _ = g.AddVertex("c")
+	if view := mustAt(t, g, 3); view.Order() != 2 || view.Vertex("b") == nil {
+		t.Fatalf("At() expected vertices b and c, got: %v", vertexIDs(view.Vertices()))
+	}
+}
+
+func mustAt(t *testing.T, g Graph, version uint64) View {
+	t.Helper()
+	view, err := g.At(version)
+	if err != nil {
+		t.Fatalf("At() expected no error, got: %v", err)
+	}
+	return view
+}
+
+func TestGraph_At_Clone(t *testing.T) {
+	g := newTxGraph()
+	if _, err := g.At(0); !errors.Is(err, ErrVersioningDisabled) {
+		t.Fatalf("At() expected error ErrVersioningDisabled, got: %v", err)
+	}
+	versioned := New(WithVersioning(), WithOrdering(InsertionOrder))
+	for v := range g.AllVertices() {
+		_, _ = versioned.AddVertex(v.Id(), WithLabel(v.Label()), WithProperty("name", v.Properties()["name"]))
+		versioned.Vertex(v.Id()).StoreData(v.Data())
+	}
+	for e := range g.AllEdges() {
+		edge, _ := versioned.AddEdge(e.Id(), e.From(), e.To(), WithLabel(e.Label()), WithProperty("weight", e.Properties()["weight"]))
+		edge.StoreData(e.Data())
+	}
+	_ = versioned.CreateVertexIndex("name", HashIndex)
+	clone := versioned.Clone()
+	for _, graph := range []Graph{versioned, clone} {
+		if view, expected := dumpGraph(mustAt(t, graph, graph.Version())), dumpGraph(g); view != expected {
+			t.Fatalf("At() expected\n%s, got:\n%s", expected, view)
+		}
+	}
+}
+
+func TestGraph_Snapshot_Concurrent(t *testing.T) {
+	g := NewConcurrent(WithVersioning())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_, _ = g.AddVertex(VertexID(fmt.Sprint(i)))
+		}
+	}()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				s, _ := g.Snapshot()
+				if order, version := s.Order(), s.Version(); uint64(order) != version {
+					t.Errorf("Snapshot() expected %d vertices at version %d, got: %d", version, version, order)
+				}
+				s.Release()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGraph_Version_LockFree(t *testing.T) {
+	g := NewConcurrent(WithVersioning())
+	_, _ = g.AddVertex("a")
+	locked := g.(*graph[any, any]).mu
+	locked.Lock()
+	defer locked.Unlock()
+	if g.Version() != 1 {
+		t.Fatalf("Version() expected 1 while the graph is locked, got: %d", g.Version())
+	}
+	if view, err := g.At(1); err != nil || view.Vertex("a") == nil {
+		t.Fatalf("At() expected vertex a while the graph is locked, got: %v", err)
+	}
+	s, err := g.Snapshot()
+	if err != nil || s.Order() != 1 {
+		t.Fatalf("Snapshot() expected vertex a while the graph is locked, got: %v", err)
+	}
+	s.Release()
+}
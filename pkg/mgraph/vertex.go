@@ -1,87 +1,228 @@
 package mgraph
 
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
 type VertexID string
 
-type Vertex interface {
+type Vertex = TypedVertex[any, any]
+
+type TypedVertex[V, E any] interface {
 	Id() VertexID
-	StoreData(data any)
-	Data() any
-	Incoming() []Edge
-	Outgoing() []Edge
-	Edges() []Edge
-	BelongsTo(edge Edge) bool
+	Label() string
+	SetProperty(property string, value any) error
+	RemoveProperty(property string) error
+	Property(property string) (any, bool)
+	Properties() map[string]any
+	Validity() Interval
+	SetValidity(from, to time.Time) error
+	StoreData(data V)
+	Data() V
+	Incoming() []TypedEdge[V, E]
+	Outgoing() []TypedEdge[V, E]
+	Edges() []TypedEdge[V, E]
+	IncomingByLabel(label string) []TypedEdge[V, E]
+	OutgoingByLabel(label string) []TypedEdge[V, E]
+	In() iter.Seq[TypedEdge[V, E]]
+	InEntries() iter.Seq2[EdgeID, TypedEdge[V, E]]
+	Out() iter.Seq[TypedEdge[V, E]]
+	OutEntries() iter.Seq2[EdgeID, TypedEdge[V, E]]
+	Neighbors() iter.Seq[TypedVertex[V, E]]
+	NeighborEntries() iter.Seq2[VertexID, TypedVertex[V, E]]
+	BelongsTo(edge TypedEdge[V, E]) bool
 	Degree() int
-	Graph() Graph
+	Graph() TypedGraph[V, E]
 }
 
-func newVertex(id VertexID, graph *graph) *vertex {
-	return &vertex{
+func newVertex[V, E any](id VertexID, label string, seq uint64, graph *graph[V, E]) *vertex[V, E] {
+	return &vertex[V, E]{
 		id:    id,
+		label: label,
+		seq:   seq,
+		mu:    graph.mu,
+		txMu:  graph.txMu,
+		hub:   graph.hub,
 		graph: graph,
 	}
 }
 
-type vertex struct {
-	id    VertexID
-	data  any
-	graph *graph
+type vertex[V, E any] struct {
+	id       VertexID
+	label    string
+	seq      uint64
+	props    map[string]any
+	validity Interval
+	mu       locker
+	txMu     locker
+	hub      *hub
+	data     V
+	graph    *graph[V, E]
 }
 
-func (v *vertex) Id() VertexID {
+func (v *vertex[V, E]) Id() VertexID {
 	return v.id
 }
 
-func (v *vertex) StoreData(data any) {
+func (v *vertex[V, E]) Label() string {
+	return v.label
+}
+
+func (v *vertex[V, E]) Validity() Interval {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.validity
+}
+
+// SetValidity sets the interval of time [from, to) during which the vertex holds.
+func (v *vertex[V, E]) SetValidity(from, to time.Time) error {
+	return v.setValidityBy(nil, from, to)
+}
+
+func (v *vertex[V, E]) setValidityBy(t *tx[V, E], from, to time.Time) error {
+	defer v.hub.publish()
+	unlock, err := lock(v.mu, v.txMu, t, func() { v.graph.commit() })
+	if err != nil {
+		return fmt.Errorf("error while setting validity of vertex '%s': %w", v.id, err)
+	}
+	defer unlock()
+	v.graph.seal()
+	v.setValidity(Interval{From: from, To: to})
+	return nil
+}
+
+func (v *vertex[V, E]) setValidity(validity Interval) {
+	if v.graph != nil {
+		old := v.validity
+		v.graph.record(func() { v.setValidity(old) })
+		v.graph.hub.emit(Event{Kind: VertexValidityChanged, Vertex: v.id})
+	}
+	v.validity = validity
+	if v.graph != nil {
+		v.graph.mirrorVertex(v)
+	}
+}
+
+func (v *vertex[V, E]) StoreData(data V) {
+	v.storeDataBy(nil, data)
+}
+
+func (v *vertex[V, E]) storeDataBy(t *tx[V, E], data V) {
+	defer v.hub.publish()
+	unlock, err := lock(v.mu, v.txMu, t, func() { v.graph.commit() })
+	if err != nil {
+		panic(fmt.Errorf("error while storing data of vertex '%s': %w", v.id, err))
+	}
+	defer unlock()
+	v.graph.seal()
+	v.storeData(data)
+}
+
+func (v *vertex[V, E]) storeData(data V) {
+	if v.graph != nil {
+		old := v.data
+		v.graph.record(func() { v.storeData(old) })
+		v.graph.hub.emit(Event{Kind: VertexDataChanged, Vertex: v.id})
+	}
 	v.data = data
+	if v.graph != nil {
+		v.graph.mirrorVertex(v)
+	}
 }
 
-func (v *vertex) Data() any {
+func (v *vertex[V, E]) Data() V {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
 	return v.data
 }
 
-func (v *vertex) Incoming() (edges []Edge) {
-	for _, e := range v.graph.edgesTo[v.id] {
+func (v *vertex[V, E]) Incoming() (edges []TypedEdge[V, E]) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.graph == nil {
+		return
+	}
+	for e := range v.graph.edgesTo[v.id].values() {
 		edges = append(edges, e)
 	}
 	return
 }
 
-func (v *vertex) Outgoing() (edges []Edge) {
-	for _, e := range v.graph.edgesFrom[v.id] {
+func (v *vertex[V, E]) Outgoing() (edges []TypedEdge[V, E]) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.graph == nil {
+		return
+	}
+	for e := range v.graph.edgesFrom[v.id].values() {
 		edges = append(edges, e)
 	}
 	return
 }
 
-func (v *vertex) Edges() []Edge {
-	edges := make([]Edge, 0, v.Degree())
-	for _, e := range v.graph.edgesTo[v.id] {
+func (v *vertex[V, E]) Edges() []TypedEdge[V, E] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.graph == nil {
+		return nil
+	}
+	edges := make([]TypedEdge[V, E], 0, v.degree())
+	for e := range v.graph.edgesTo[v.id].values() {
 		edges = append(edges, e)
 	}
-	for _, e := range v.graph.edgesFrom[v.id] {
-		if !e.IsLoop() {
+	for e := range v.graph.edgesFrom[v.id].values() {
+		if e.directed && !e.IsLoop() {
 			edges = append(edges, e)
 		}
 	}
+	if v.graph.properties.ordering != Unordered {
+		slices.SortFunc(edges, func(a, b TypedEdge[V, E]) int {
+			return v.graph.edges.compare(a.Id(), b.Id())
+		})
+	}
 	return edges
 }
 
-func (v *vertex) BelongsTo(edge Edge) bool {
+func (v *vertex[V, E]) BelongsTo(edge TypedEdge[V, E]) bool {
 	return v.id == edge.To() || v.id == edge.From()
 }
 
-func (v *vertex) Degree() int {
-	return len(v.graph.edgesTo[v.id]) + len(v.graph.edgesFrom[v.id])
+func (v *vertex[V, E]) Degree() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.degree()
+}
+
+// degree counts loops twice and every other incident edge once.
+func (v *vertex[V, E]) degree() int {
+	if v.graph == nil {
+		return 0
+	}
+	degree := v.graph.edgesTo[v.id].len() + v.graph.edgesFrom[v.id].len()
+	if v.graph.properties.directedness != Directed {
+		for e := range v.graph.edgesFrom[v.id].values() {
+			if !e.directed && !e.IsLoop() {
+				degree--
+			}
+		}
+	}
+	return degree
 }
 
-func (v *vertex) Graph() Graph {
+func (v *vertex[V, E]) Graph() TypedGraph[V, E] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
 	if v.graph != nil {
 		return v.graph
 	}
 	return nil
 }
 
-func (v *vertex) onRemove() {
-	v.data = nil
+func (v *vertex[V, E]) onRemove() {
+	var zero V
+	v.data = zero
 	v.graph = nil
 }
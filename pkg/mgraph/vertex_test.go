@@ -22,6 +22,25 @@ func TestVertex_Data(t *testing.T) {
 	}
 }
 
+func TestTypedVertex_Data(t *testing.T) {
+	type user struct {
+		username string
+	}
+	g := NewTyped[user, int]()
+	v, _ := g.AddVertex("node1")
+	if v.Data() != (user{}) {
+		t.Errorf("Data() expected zero value, got %v", v.Data())
+	}
+	v.StoreData(user{username: "john"})
+	if v.Data().username != "john" {
+		t.Errorf("Data() expected 'john', got %s", v.Data().username)
+	}
+	g.RemoveVertex("node1")
+	if v.Data() != (user{}) {
+		t.Errorf("Data() expected zero value after remove, got %v", v.Data())
+	}
+}
+
 func TestVertex_Graph(t *testing.T) {
 	g := New()
 	v, _ := g.AddVertex("node1")